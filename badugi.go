@@ -0,0 +1,169 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BadugiHand is a four-card hand. Only cards of distinct suits and ranks
+// play, aces are low and the lowest badugi wins.
+type BadugiHand struct {
+	cards      [4]Card
+	badugiRank BadugiRank
+	ranks      [4]Rank
+}
+
+// lowRank returns the rank with aces counted below deuces.
+func lowRank(r Rank) Rank {
+	if r == Ace {
+		return 1
+	}
+	return r
+}
+
+// score picks the largest subset of cards with no shared suit or rank and,
+// among subsets of that size, the one with the lowest cards.
+func (h *BadugiHand) score() {
+	h.best()
+}
+
+// best scores the hand and returns the cards of the badugi it plays as a
+// mask of their indices.
+func (h *BadugiHand) best() int {
+	h.badugiRank = 0
+	h.ranks = [4]Rank{}
+	best := 0
+
+	for mask := 1; mask < 1<<len(h.cards); mask++ {
+		var suits, ranks uint16
+		var used [4]Rank
+		n := 0
+		valid := true
+		for i, c := range h.cards {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			if suits&(1<<c.suit) != 0 || ranks&(1<<c.rank) != 0 {
+				valid = false
+				break
+			}
+			suits |= 1 << c.suit
+			ranks |= 1 << c.rank
+			used[n] = lowRank(c.rank)
+			n++
+		}
+		if !valid {
+			continue
+		}
+		sort.Slice(used[:n], func(i, j int) bool { return used[i] > used[j] })
+		b := BadugiHand{badugiRank: BadugiRank(n), ranks: used}
+		if compareBadugi(&b, h) > 0 {
+			h.badugiRank = b.badugiRank
+			h.ranks = b.ranks
+			best = mask
+		}
+	}
+
+	return best
+}
+
+// compareBadugi returns a positive number if a beats b, a negative number if
+// b beats a and zero on a split. More cards beat fewer, then the lowest
+// highest card wins.
+func compareBadugi(a, b *BadugiHand) int {
+	if a.badugiRank != b.badugiRank {
+		return int(a.badugiRank) - int(b.badugiRank)
+	}
+	for i, r := range a.ranks {
+		if r != b.ranks[i] {
+			return int(b.ranks[i]) - int(r)
+		}
+	}
+
+	return 0
+}
+
+func badugiPlay(hands []BadugiHand) []BadugiHand {
+	winners := make([]BadugiHand, 0)
+
+	for _, h := range hands {
+		h.score()
+		if len(winners) == 0 {
+			winners = append(winners, h)
+			continue
+		}
+		switch c := compareBadugi(&h, &winners[0]); {
+		case c > 0:
+			winners = winners[:0]
+			winners = append(winners, h)
+		case c == 0:
+			winners = append(winners, h)
+		}
+	}
+
+	return winners
+}
+
+// badugiDiscard keeps the cards of the best badugi that are lower than a
+// nine and draws to the rest.
+func badugiDiscard(cards []Card) []int {
+	h := BadugiHand{cards: [4]Card(cards)}
+	best := h.best()
+	idx := make([]int, 0)
+	for i, c := range cards {
+		if best&(1<<i) != 0 && lowRank(c.rank) < Nine {
+			continue
+		}
+		idx = append(idx, i)
+	}
+
+	return idx
+}
+
+// badugiDeal deals four cards to every player and runs the three draws.
+func badugiDeal(numHands int, discard drawStrategy) ([]BadugiHand, error) {
+	cards, s, err := dealDraw(numHands, 4)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawRounds(cards, s, 3, discard); err != nil {
+		return nil, err
+	}
+
+	hands := make([]BadugiHand, numHands)
+	for i := range hands {
+		hands[i].cards = [4]Card(cards[i])
+	}
+
+	return hands, nil
+}
+
+func (h *BadugiHand) String() string {
+	buf := ""
+	for _, c := range h.cards {
+		buf += fmt.Sprintf("%s:%s ", c.rank, c.suit)
+	}
+
+	return buf
+}
+
+func badugi(numHands int) error {
+	hands, err := badugiDeal(numHands, badugiDiscard)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	for _, h := range hands {
+		fmt.Printf("%s\n", h.String())
+	}
+
+	winners := badugiPlay(hands)
+	fmt.Printf("%d Winner(s):\n", len(winners))
+
+	for _, w := range winners {
+		fmt.Printf("%s: %s, %s\n", w.String(), w.badugiRank, w.ranks)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBadugiHand_score(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     [4]Card
+		wantScore BadugiRank
+		wantRanks [4]Rank
+	}{
+		{
+			name: "four card badugi",
+			cards: [4]Card{
+				{rank: Ace, suit: Spade},
+				{rank: Two, suit: Diamond},
+				{rank: Three, suit: Heart},
+				{rank: Four, suit: Club},
+			},
+			wantScore: Badugi,
+			wantRanks: [4]Rank{Four, Three, Two, Rank(1)},
+		},
+		{
+			name: "paired three card",
+			cards: [4]Card{
+				{rank: King, suit: Spade},
+				{rank: Two, suit: Diamond},
+				{rank: Two, suit: Heart},
+				{rank: Four, suit: Club},
+			},
+			wantScore: ThreeCardBadugi,
+			wantRanks: [4]Rank{King, Four, Two},
+		},
+		{
+			name: "suited picks the lowest",
+			cards: [4]Card{
+				{rank: King, suit: Spade},
+				{rank: Two, suit: Spade},
+				{rank: Five, suit: Heart},
+				{rank: Seven, suit: Heart},
+			},
+			wantScore: TwoCardBadugi,
+			wantRanks: [4]Rank{Five, Two},
+		},
+		{
+			name: "one card",
+			cards: [4]Card{
+				{rank: King, suit: Club},
+				{rank: King, suit: Spade},
+				{rank: King, suit: Heart},
+				{rank: King, suit: Diamond},
+			},
+			wantScore: OneCardBadugi,
+			wantRanks: [4]Rank{King},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := BadugiHand{
+				cards: tt.cards,
+			}
+			h.score()
+			if got := h.badugiRank; got != tt.wantScore {
+				t.Errorf("score() = %v, want %v", got, tt.wantScore)
+			}
+			if got := h.ranks; got != tt.wantRanks {
+				t.Errorf("score() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+}
+
+func Test_badugiPlay(t *testing.T) {
+	three := BadugiHand{cards: [4]Card{
+		{rank: Ace, suit: Spade},
+		{rank: Two, suit: Diamond},
+		{rank: Three, suit: Heart},
+		{rank: Four, suit: Heart},
+	}}
+	king := BadugiHand{cards: [4]Card{
+		{rank: King, suit: Spade},
+		{rank: Queen, suit: Diamond},
+		{rank: Jack, suit: Heart},
+		{rank: Ten, suit: Club},
+	}}
+	eight := BadugiHand{cards: [4]Card{
+		{rank: Eight, suit: Spade},
+		{rank: Two, suit: Diamond},
+		{rank: Three, suit: Heart},
+		{rank: Four, suit: Club},
+	}}
+	winners := badugiPlay([]BadugiHand{three, king, eight})
+	if len(winners) != 1 || winners[0].ranks[0] != Eight {
+		t.Errorf("badugiPlay() = %v, want eight badugi", winners)
+	}
+}
+
+func Test_badugiDiscard(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []Card
+		want  []int
+	}{
+		{
+			name:  "pat badugi",
+			cards: []Card{{rank: Ace, suit: Spade}, {rank: Two, suit: Diamond}, {rank: Three, suit: Club}, {rank: Four, suit: Heart}},
+			want:  []int{},
+		},
+		{
+			name:  "high card",
+			cards: []Card{{rank: Two, suit: Spade}, {rank: Three, suit: Diamond}, {rank: Four, suit: Club}, {rank: King, suit: Heart}},
+			want:  []int{3},
+		},
+		{
+			// keeping the first deuce would clash with the four of diamonds
+			name:  "paired deuces",
+			cards: []Card{{rank: Two, suit: Diamond}, {rank: Three, suit: Heart}, {rank: Two, suit: Spade}, {rank: Four, suit: Diamond}},
+			want:  []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := badugiDiscard(tt.cards); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("badugiDiscard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_badugi(t *testing.T) {
+	if err := badugi(4); err != nil {
+		t.Errorf("badugi() error = %v", err)
+	}
+	if err := badugi(14); err == nil {
+		t.Errorf("badugi() error = nil, want not enough cards")
+	}
+}
+
+func TestBadugiRank_String(t *testing.T) {
+	if got := Badugi.String(); got != "Badugi" {
+		t.Errorf("String() = %v, want Badugi", got)
+	}
+	if got := ThreeCardBadugi.String(); got != "ThreeCardBadugi" {
+		t.Errorf("String() = %v, want ThreeCardBadugi", got)
+	}
+}
@@ -0,0 +1,60 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_caribbeanSettle(t *testing.T) {
+	tests := []struct {
+		name   string
+		player [5]Card
+		dealer [5]Card
+		raise  bool
+		want   float64
+	}{
+		{
+			name:   "fold",
+			player: [5]Card{{Jack, Spade}, {Six, Heart}, {Two, Club}, {Four, Club}, {Nine, Club}},
+			dealer: [5]Card{{King, Spade}, {Six, Diamond}, {Two, Diamond}, {Four, Spade}, {Eight, Club}},
+			want:   -10,
+		},
+		{
+			name:   "dealer does not qualify",
+			player: [5]Card{{Jack, Spade}, {Jack, Heart}, {Two, Club}, {Four, Club}, {Nine, Club}},
+			dealer: [5]Card{{Ace, Spade}, {Queen, Diamond}, {Two, Diamond}, {Four, Spade}, {Eight, Club}},
+			raise:  true,
+			want:   10,
+		},
+		{
+			name:   "flush pays five to one on the raise",
+			player: [5]Card{{Jack, Club}, {Six, Club}, {Two, Club}, {Four, Club}, {Nine, Club}},
+			dealer: [5]Card{{Ace, Spade}, {King, Diamond}, {Two, Diamond}, {Four, Spade}, {Eight, Heart}},
+			raise:  true,
+			want:   10 + 100,
+		},
+		{
+			name:   "lose to a qualifying dealer",
+			player: [5]Card{{Jack, Spade}, {Jack, Heart}, {Two, Club}, {Four, Club}, {Nine, Club}},
+			dealer: [5]Card{{Queen, Spade}, {Queen, Diamond}, {Two, Diamond}, {Four, Spade}, {Eight, Club}},
+			raise:  true,
+			want:   -30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := caribbeanSettle(tt.player, tt.dealer, 10, tt.raise, caribbeanPays); got != tt.want {
+				t.Errorf("caribbeanSettle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_caribbeanEdge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every player hand")
+	}
+	if got := caribbeanEdge(caribbeanPays); math.Abs(got-0.053163) > 1e-6 {
+		t.Errorf("caribbeanEdge() = %.6f, want 0.053163", got)
+	}
+}
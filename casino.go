@@ -0,0 +1,150 @@
+package poker
+
+import "sort"
+
+// payout is a house-banked paytable keyed by category, in units won per unit
+// bet. The royal flush pays from its own line when set.
+type payout struct {
+	royal float64
+	pays  map[HandRank]float64
+}
+
+func (p payout) pay(v handValue) float64 {
+	if p.royal != 0 && v.handRank() == StraightFlush && v.ranks()[0] == Ace {
+		return p.royal
+	}
+	return p.pays[v.handRank()]
+}
+
+// subsetCounts counts, for every set of up to five cards, how many of the
+// hands added so far contain it. By inclusion-exclusion that answers how many
+// added hands share no card with a given hand, which is what enumerating a
+// dealer hand against every player hand needs.
+type subsetCounts [6][]int32
+
+func newSubsetCounts(size int) *subsetCounts {
+	var s subsetCounts
+	for k := 0; k <= size; k++ {
+		s[k] = make([]int32, binomial[52][k])
+	}
+	return &s
+}
+
+// subsets calls f with the colex rank and size of every subset of idx, which
+// must be in ascending order.
+func subsets(idx []int, f func(k int, rank int64)) {
+	var sub [5]int
+	for mask := 0; mask < 1<<len(idx); mask++ {
+		n := 0
+		for i, c := range idx {
+			if mask&(1<<i) != 0 {
+				sub[n] = c
+				n++
+			}
+		}
+		f(n, colex(sub[:n]))
+	}
+}
+
+func (s *subsetCounts) add(idx []int) {
+	subsets(idx, func(k int, rank int64) { s[k][rank]++ })
+}
+
+// disjoint returns how many added hands share no card with idx.
+func (s *subsetCounts) disjoint(idx []int) int {
+	n := 0
+	subsets(idx, func(k int, rank int64) {
+		if k%2 == 1 {
+			n -= int(s[k][rank])
+		} else {
+			n += int(s[k][rank])
+		}
+	})
+	return n
+}
+
+// rankedHand is one hand of an enumeration: its card indices in ascending
+// order and its value.
+type rankedHand struct {
+	idx   [5]int
+	value int
+}
+
+// enumerateHands returns every size-card hand from the deck, valued by f.
+func enumerateHands(size int, f func(cards []Card) int) []rankedHand {
+	cards := deck()
+	hands := make([]rankedHand, 0, binomial[52][size])
+	var idx [5]int
+	hand := make([]Card, size)
+	var rec func(k, from int)
+	rec = func(k, from int) {
+		if k == size {
+			for i := range hand {
+				hand[i] = cards[idx[i]]
+			}
+			hands = append(hands, rankedHand{idx: idx, value: f(hand)})
+			return
+		}
+		for i := from; i < 52; i++ {
+			idx[k] = i
+			rec(k+1, i+1)
+		}
+	}
+	rec(0, 0)
+
+	return hands
+}
+
+// dealerOdds counts, for a player hand, the dealer hands that share no card
+// with it: those that fail to qualify and, of the qualifying ones, those
+// below, level with and above the player.
+type dealerOdds struct {
+	unqualified, below, level, above int
+}
+
+// enumerateDealer works out the dealer odds of every player hand of the given
+// size, where dealer hands valued below qualify do not qualify.
+func enumerateDealer(hands []rankedHand, size, qualify int) []dealerOdds {
+	order := make([]int, len(hands))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return hands[order[i]].value < hands[order[j]].value })
+
+	total := int(binomial[52-size][size])
+	odds := make([]dealerOdds, len(hands))
+	counts := newSubsetCounts(size)
+
+	i := 0
+	for ; i < len(order) && hands[order[i]].value < qualify; i++ {
+		counts.add(hands[order[i]].idx[:size])
+	}
+	for h := range hands {
+		odds[h].unqualified = counts.disjoint(hands[h].idx[:size])
+	}
+	for i < len(order) {
+		j := i
+		for j < len(order) && hands[order[j]].value == hands[order[i]].value {
+			j++
+		}
+		for _, h := range order[i:j] {
+			odds[h].below = counts.disjoint(hands[h].idx[:size]) - odds[h].unqualified
+		}
+		for _, h := range order[i:j] {
+			counts.add(hands[h].idx[:size])
+		}
+		for _, h := range order[i:j] {
+			lessEq := counts.disjoint(hands[h].idx[:size])
+			odds[h].level = lessEq - odds[h].unqualified - odds[h].below
+			odds[h].above = total - lessEq
+		}
+		i = j
+	}
+	for h := range hands {
+		if hands[h].value < qualify {
+			odds[h].above = total - odds[h].unqualified
+		}
+	}
+
+	return odds
+}
@@ -0,0 +1,43 @@
+package poker
+
+import (
+	"testing"
+)
+
+func Test_enumerateDealer(t *testing.T) {
+	value := func(cards []Card) int {
+		h := scoreThreeCards([3]Card(cards))
+		return threeValue(&h)
+	}
+	hands := enumerateHands(3, value)
+	if len(hands) != 22100 {
+		t.Fatalf("enumerateHands() = %d hands, want 22100", len(hands))
+	}
+	odds := enumerateDealer(hands, 3, threeCardQualifier)
+
+	cards := deck()
+	for _, i := range []int{0, 777, 5000, 12345, 22099} {
+		h := hands[i]
+		var want dealerOdds
+		for _, d := range hands {
+			if d.idx[0] == h.idx[0] || d.idx[0] == h.idx[1] || d.idx[0] == h.idx[2] ||
+				d.idx[1] == h.idx[0] || d.idx[1] == h.idx[1] || d.idx[1] == h.idx[2] ||
+				d.idx[2] == h.idx[0] || d.idx[2] == h.idx[1] || d.idx[2] == h.idx[2] {
+				continue
+			}
+			switch v := value([]Card{cards[d.idx[0]], cards[d.idx[1]], cards[d.idx[2]]}); {
+			case v < threeCardQualifier:
+				want.unqualified++
+			case v < h.value:
+				want.below++
+			case v == h.value:
+				want.level++
+			default:
+				want.above++
+			}
+		}
+		if odds[i] != want {
+			t.Errorf("enumerateDealer() hand %d = %+v, want %+v", i, odds[i], want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// categoryNames are the hand categories as players say them.
+var categoryNames = [...]string{
+	HighCard:      "High Card",
+	Pair:          "Pair",
+	TwoPair:       "Two Pair",
+	ThreeOfAKind:  "Three of a Kind",
+	Straight:      "Straight",
+	Flush:         "Flush",
+	FullHouse:     "Full House",
+	FourOfAKind:   "Four of a Kind",
+	StraightFlush: "Straight Flush",
+}
+
+func categoryName(r HandRank) string {
+	if r < 0 || int(r) >= len(categoryNames) {
+		return r.String()
+	}
+	return categoryNames[r]
+}
+
+// plural names more than one card of a rank: Aces, Sixes.
+func plural(r Rank) string {
+	if r == Six {
+		return "Sixes"
+	}
+	return r.String() + "s"
+}
+
+// list joins ranks as "King, Nine and Four".
+func list(ranks []Rank) string {
+	names := make([]string, 0, len(ranks))
+	for _, r := range ranks {
+		if r != 0 {
+			names = append(names, r.String())
+		}
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// describe says what a hand is, such as "Full House, Aces full of Tens" or
+// "Two Pair, Kings and Fives with a Queen kicker".
+func describe(r HandRank, ranks [5]Rank) string {
+	name := categoryName(r)
+	switch r {
+	case HighCard:
+		return fmt.Sprintf("%s, %s high with %s", name, ranks[0], list(ranks[1:]))
+	case Pair:
+		return fmt.Sprintf("%s of %s with %s", name, plural(ranks[0]), list(ranks[1:]))
+	case TwoPair:
+		return fmt.Sprintf("%s, %s and %s with a%s %s kicker", name, plural(ranks[0]), plural(ranks[1]), article(ranks[2]), ranks[2])
+	case ThreeOfAKind:
+		return fmt.Sprintf("%s, %s with %s", name, plural(ranks[0]), list(ranks[1:]))
+	case Straight:
+		if ranks[0] == Five {
+			return name + ", Five high (wheel)"
+		}
+		return fmt.Sprintf("%s, %s high", name, ranks[0])
+	case Flush:
+		return fmt.Sprintf("%s, %s high with %s", name, ranks[0], list(ranks[1:]))
+	case FullHouse:
+		return fmt.Sprintf("%s, %s full of %s", name, plural(ranks[0]), plural(ranks[1]))
+	case FourOfAKind:
+		return fmt.Sprintf("%s, %s with a%s %s kicker", name, plural(ranks[0]), article(ranks[1]), ranks[1])
+	case StraightFlush:
+		switch ranks[0] {
+		case Ace:
+			return "Royal Flush"
+		case Five:
+			return name + ", Five high (steel wheel)"
+		}
+		return fmt.Sprintf("%s, %s high", name, ranks[0])
+	}
+
+	return name
+}
+
+// article completes "a" before a rank: "an Ace", "an Eight".
+func article(r Rank) string {
+	if r == Ace || r == Eight {
+		return "n"
+	}
+	return ""
+}
+
+// describe says what a scored hand is.
+func (h *Hand) describe() string {
+	return describe(h.handRank, h.ranks)
+}
+
+func (v handValue) describe() string {
+	return describe(v.handRank(), v.ranks())
+}
+
+// tieBreaks names what each rank of a category stands for, in the order
+// hands compare on them.
+var tieBreaks = [...][]string{
+	HighCard:      {"high card", "first kicker", "second kicker", "third kicker", "fourth kicker"},
+	Pair:          {"pair", "first kicker", "second kicker", "third kicker"},
+	TwoPair:       {"top pair", "second pair", "kicker"},
+	ThreeOfAKind:  {"trips", "first kicker", "second kicker"},
+	Straight:      {"higher straight"},
+	Flush:         {"high card", "second card", "third card", "fourth card", "fifth card"},
+	FullHouse:     {"trips", "pair"},
+	FourOfAKind:   {"quads", "kicker"},
+	StraightFlush: {"higher straight flush"},
+}
+
+// explain says why hand a beats hand b, such as "Full House beats Flush" or
+// "wins on second kicker (Nine over Seven)", or "split pot" on a tie.
+func explain(a, b *Hand) string {
+	if a.handRank != b.handRank {
+		return fmt.Sprintf("%s beats %s", categoryName(a.handRank), categoryName(b.handRank))
+	}
+	for i, r := range a.ranks {
+		if r == b.ranks[i] {
+			continue
+		}
+		what := "kicker"
+		if names := tieBreaks[a.handRank]; i < len(names) {
+			what = names[i]
+		}
+		if i == 0 && (a.handRank == Straight || a.handRank == StraightFlush) {
+			return fmt.Sprintf("wins on %s (%s high over %s high)", what, r, b.ranks[i])
+		}
+		return fmt.Sprintf("wins on %s (%s over %s)", what, r, b.ranks[i])
+	}
+
+	return "split pot"
+}
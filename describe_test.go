@@ -0,0 +1,79 @@
+package poker
+
+import "testing"
+
+func Test_describe(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards [5]Card
+		want  string
+	}{
+		{"high card", [5]Card{{Ace, Spade}, {Nine, Heart}, {King, Club}, {Four, Club}, {Seven, Diamond}}, "High Card, Ace high with King, Nine, Seven and Four"},
+		{"pair", [5]Card{{Six, Spade}, {Six, Heart}, {King, Club}, {Four, Club}, {Two, Diamond}}, "Pair of Sixes with King, Four and Two"},
+		{"two pair", [5]Card{{King, Spade}, {Five, Heart}, {King, Club}, {Queen, Club}, {Five, Diamond}}, "Two Pair, Kings and Fives with a Queen kicker"},
+		{"trips", [5]Card{{Seven, Spade}, {Seven, Heart}, {Seven, Club}, {Ace, Club}, {Jack, Diamond}}, "Three of a Kind, Sevens with Ace and Jack"},
+		{"straight", [5]Card{{Nine, Spade}, {Eight, Heart}, {Seven, Club}, {Six, Club}, {Five, Diamond}}, "Straight, Nine high"},
+		{"wheel", [5]Card{{Ace, Spade}, {Two, Heart}, {Three, Club}, {Four, Club}, {Five, Diamond}}, "Straight, Five high (wheel)"},
+		{"flush", [5]Card{{Ace, Heart}, {Two, Heart}, {Nine, Heart}, {Six, Heart}, {King, Heart}}, "Flush, Ace high with King, Nine, Six and Two"},
+		{"full house", [5]Card{{Ace, Spade}, {Ten, Heart}, {Ace, Club}, {Ten, Club}, {Ace, Diamond}}, "Full House, Aces full of Tens"},
+		{"quads", [5]Card{{Queen, Spade}, {Queen, Heart}, {Queen, Club}, {Queen, Diamond}, {Eight, Diamond}}, "Four of a Kind, Queens with an Eight kicker"},
+		{"straight flush", [5]Card{{Nine, Club}, {Eight, Club}, {Seven, Club}, {Six, Club}, {Five, Club}}, "Straight Flush, Nine high"},
+		{"steel wheel", [5]Card{{Ace, Club}, {Two, Club}, {Three, Club}, {Four, Club}, {Five, Club}}, "Straight Flush, Five high (steel wheel)"},
+		{"royal", [5]Card{{Ace, Club}, {King, Club}, {Queen, Club}, {Jack, Club}, {Ten, Club}}, "Royal Flush"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hand{cards: tt.cards}
+			h.score()
+			if got := h.describe(); got != tt.want {
+				t.Errorf("describe() = %q, want %q", got, tt.want)
+			}
+			if got := evaluate(tt.cards[:]).describe(); got != tt.want {
+				t.Errorf("handValue describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_explain(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b [5]Card
+		want string
+	}{
+		{
+			name: "category",
+			a:    [5]Card{{Ace, Spade}, {Ten, Heart}, {Ace, Club}, {Ten, Club}, {Ace, Diamond}},
+			b:    [5]Card{{Ace, Heart}, {Two, Heart}, {Nine, Heart}, {Six, Heart}, {King, Heart}},
+			want: "Full House beats Flush",
+		},
+		{
+			name: "second kicker",
+			a:    [5]Card{{Six, Spade}, {Six, Heart}, {King, Club}, {Nine, Club}, {Two, Diamond}},
+			b:    [5]Card{{Six, Club}, {Six, Diamond}, {King, Heart}, {Seven, Club}, {Four, Diamond}},
+			want: "wins on second kicker (Nine over Seven)",
+		},
+		{
+			name: "straight over the wheel",
+			a:    [5]Card{{Six, Spade}, {Two, Heart}, {Three, Club}, {Four, Club}, {Five, Diamond}},
+			b:    [5]Card{{Ace, Spade}, {Two, Club}, {Three, Heart}, {Four, Heart}, {Five, Heart}},
+			want: "wins on higher straight (Six high over Five high)",
+		},
+		{
+			name: "split",
+			a:    [5]Card{{Six, Spade}, {Two, Heart}, {Three, Club}, {Four, Club}, {Five, Diamond}},
+			b:    [5]Card{{Six, Heart}, {Two, Club}, {Three, Heart}, {Four, Heart}, {Five, Heart}},
+			want: "split pot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Hand{cards: tt.a}, Hand{cards: tt.b}
+			a.score()
+			b.score()
+			if got := explain(&a, &b); got != tt.want {
+				t.Errorf("explain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
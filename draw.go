@@ -0,0 +1,67 @@
+package poker
+
+import "fmt"
+
+// drawStrategy picks the indices of the cards a player throws away on a draw.
+type drawStrategy func(cards []Card) []int
+
+// stub holds the undealt part of a shuffled deck. Discards go to the muck and
+// are reshuffled into the stub when it runs out in the middle of a draw.
+type stub struct {
+	cards []Card
+	muck  []Card
+}
+
+func newStub() *stub {
+	cards := deck()
+	shuffle(cards)
+	return &stub{cards: cards}
+}
+
+func (s *stub) draw(n int) ([]Card, error) {
+	if n > len(s.cards) {
+		s.cards = append(s.cards, s.muck...)
+		s.muck = nil
+		shuffle(s.cards)
+	}
+	if n > len(s.cards) {
+		return nil, fmt.Errorf("not enough cards in the deck")
+	}
+	cards := s.cards[:n:n]
+	s.cards = s.cards[n:]
+
+	return cards, nil
+}
+
+// drawRounds replaces the cards picked by the strategy in every hand, once per
+// round, going around the table in order.
+func drawRounds(hands [][]Card, s *stub, rounds int, discard drawStrategy) error {
+	for r := 0; r < rounds; r++ {
+		for _, cards := range hands {
+			idx := discard(cards)
+			drawn, err := s.draw(len(idx))
+			if err != nil {
+				return err
+			}
+			for i, j := range idx {
+				s.muck = append(s.muck, cards[j])
+				cards[j] = drawn[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func dealDraw(numHands, size int) ([][]Card, *stub, error) {
+	s := newStub()
+	if size*numHands > len(s.cards) {
+		return nil, nil, fmt.Errorf("not enough cards in the deck")
+	}
+	hands := make([][]Card, numHands)
+	for i := range hands {
+		hands[i], _ = s.draw(size)
+	}
+
+	return hands, s, nil
+}
@@ -0,0 +1,299 @@
+package poker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Cards are written as their rank and suit letters, "As" or "Td", and packed
+// into one byte as their index, suit*13 + rank-2. The joker is "Jk" and 52.
+// Hands are written as their five cards, and in JSON with the category and
+// ranks they evaluate to as well.
+
+func (r Rank) MarshalText() ([]byte, error) {
+	if r < Two || r > Ace {
+		return nil, fmt.Errorf("invalid rank %d", int(r))
+	}
+	return []byte{rankChar(r)}, nil
+}
+
+func (r *Rank) UnmarshalText(text []byte) error {
+	i := strings.IndexByte(rankChars, upper(text))
+	if len(text) != 1 || i < 0 {
+		return fmt.Errorf("invalid rank %q", text)
+	}
+	*r = Rank(i + 2)
+	return nil
+}
+
+func (s Suit) MarshalText() ([]byte, error) {
+	if s < Spade || s > Heart {
+		return nil, fmt.Errorf("invalid suit %d", int(s))
+	}
+	return []byte{suitChars[s]}, nil
+}
+
+func (s *Suit) UnmarshalText(text []byte) error {
+	i := -1
+	if len(text) == 1 {
+		i = strings.IndexByte(suitChars, lower(text[0]))
+	}
+	if i < 0 {
+		return fmt.Errorf("invalid suit %q", text)
+	}
+	*s = Suit(i)
+	return nil
+}
+
+func upper(text []byte) byte {
+	if len(text) == 0 {
+		return 0
+	}
+	if c := text[0]; c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return text[0]
+}
+
+func lower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c - 'A' + 'a'
+	}
+	return c
+}
+
+// MarshalText writes a category by its name, such as "FullHouse".
+func (r HandRank) MarshalText() ([]byte, error) {
+	if r < HighCard || r > StraightFlush {
+		return nil, fmt.Errorf("invalid hand rank %d", int(r))
+	}
+	return []byte(r.String()), nil
+}
+
+func (r *HandRank) UnmarshalText(text []byte) error {
+	for c := HighCard; c <= StraightFlush; c++ {
+		if c.String() == string(text) {
+			*r = c
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid hand rank %q", text)
+}
+
+func (c Card) MarshalText() ([]byte, error) {
+	if c == joker {
+		return []byte(jokerText), nil
+	}
+	r, err := c.rank.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.suit.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(r, s...), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	if string(text) == jokerText {
+		*c = joker
+		return nil
+	}
+	if len(text) != 2 {
+		return fmt.Errorf("invalid card %q", text)
+	}
+	if err := c.rank.UnmarshalText(text[:1]); err != nil {
+		return fmt.Errorf("invalid card %q: %w", text, err)
+	}
+	if err := c.suit.UnmarshalText(text[1:]); err != nil {
+		return fmt.Errorf("invalid card %q: %w", text, err)
+	}
+	return nil
+}
+
+func (c Card) MarshalBinary() ([]byte, error) {
+	if c == joker {
+		return []byte{52}, nil
+	}
+	if c.rank < Two || c.rank > Ace || c.suit < Spade || c.suit > Heart {
+		return nil, fmt.Errorf("invalid card %d:%d", int(c.rank), int(c.suit))
+	}
+	return []byte{byte(int(c.suit)*13 + int(c.rank) - 2)}, nil
+}
+
+func (c *Card) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 || data[0] > 52 {
+		return fmt.Errorf("invalid card encoding %x", data)
+	}
+	if data[0] == 52 {
+		*c = joker
+		return nil
+	}
+	*c = Card{rank: Rank(data[0]%13 + 2), suit: Suit(data[0] / 13)}
+	return nil
+}
+
+// parseCards reads cards separated by spaces, such as "As Kd".
+func parseCards(s string) ([]Card, error) {
+	fields := strings.Fields(s)
+	cards := make([]Card, len(fields))
+	for i, f := range fields {
+		if err := cards[i].UnmarshalText([]byte(f)); err != nil {
+			return nil, err
+		}
+	}
+	return cards, nil
+}
+
+// formatCards writes cards separated by spaces.
+func formatCards(cards []Card) string {
+	return Compact.Render(cards)
+}
+
+// handOf scores five cards as a hand, refusing repeated cards.
+func handOf(cards []Card) (*Hand, error) {
+	if len(cards) != 5 {
+		return nil, fmt.Errorf("a hand has 5 cards, not %d", len(cards))
+	}
+	h := &Hand{}
+	for i, c := range cards {
+		for _, d := range cards[:i] {
+			if c == d {
+				return nil, fmt.Errorf("card %s is repeated", formatCards([]Card{c}))
+			}
+		}
+		h.cards[i] = c
+	}
+	h.score()
+	return h, nil
+}
+
+func (h Hand) MarshalText() ([]byte, error) {
+	for _, c := range h.cards {
+		if _, err := c.MarshalText(); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(formatCards(h.cards[:])), nil
+}
+
+// UnmarshalText reads five cards and scores them.
+func (h *Hand) UnmarshalText(text []byte) error {
+	cards, err := parseCards(string(text))
+	if err != nil {
+		return err
+	}
+	scored, err := handOf(cards)
+	if err != nil {
+		return err
+	}
+	*h = *scored
+	return nil
+}
+
+func (h Hand) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(h.cards))
+	for _, c := range h.cards {
+		b, err := c.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+func (h *Hand) UnmarshalBinary(data []byte) error {
+	if len(data) != 5 {
+		return fmt.Errorf("a hand has 5 cards, not %d", len(data))
+	}
+	cards := make([]Card, 5)
+	for i := range cards {
+		if err := cards[i].UnmarshalBinary(data[i : i+1]); err != nil {
+			return err
+		}
+	}
+	scored, err := handOf(cards)
+	if err != nil {
+		return err
+	}
+	*h = *scored
+	return nil
+}
+
+func sameRanks(a, b []Rank) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatRanks writes ranks as their letters.
+func formatRanks(ranks []Rank) string {
+	buf := make([]byte, 0, len(ranks))
+	for _, r := range ranks {
+		if r < Two || r > Ace {
+			buf = append(buf, '?')
+			continue
+		}
+		buf = append(buf, rankChar(r))
+	}
+	return string(buf)
+}
+
+// handJSON is how a hand looks in JSON. The category and ranks are written
+// for readers that do not evaluate hands themselves and are checked on the
+// way back in.
+type handJSON struct {
+	Cards    []Card   `json:"cards"`
+	Category HandRank `json:"category"`
+	Ranks    []Rank   `json:"ranks"`
+}
+
+func (h Hand) MarshalJSON() ([]byte, error) {
+	// dealt hands are not scored until they are played
+	h.score()
+	return json.Marshal(handJSON{Cards: h.cards[:], Category: h.handRank, Ranks: jsonRanks(h.ranks)})
+}
+
+// jsonRanks lists the ranks a scored hand is compared by, with the ace of a
+// wheel as an ace.
+func jsonRanks(scored [5]Rank) []Rank {
+	ranks := make([]Rank, 0, len(scored))
+	for _, r := range scored {
+		switch {
+		case r == 1:
+			// the ace plays low in a wheel
+			ranks = append(ranks, Ace)
+		case r != 0:
+			ranks = append(ranks, r)
+		}
+	}
+	return ranks
+}
+
+func (h *Hand) UnmarshalJSON(data []byte) error {
+	var v handJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	scored, err := handOf(v.Cards)
+	if err != nil {
+		return err
+	}
+	if scored.handRank != v.Category {
+		return fmt.Errorf("hand %s is a %s, not a %s", formatCards(v.Cards), scored.handRank, v.Category)
+	}
+	if ranks := jsonRanks(scored.ranks); !sameRanks(ranks, v.Ranks) {
+		return fmt.Errorf("hand %s ranks %s, not %s", formatCards(v.Cards), formatRanks(ranks), formatRanks(v.Ranks))
+	}
+	*h = *scored
+	return nil
+}
@@ -0,0 +1,165 @@
+package poker
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestCard_text(t *testing.T) {
+	// 52 is the joker
+	for i := byte(0); i <= 52; i++ {
+		var c Card
+		if err := c.UnmarshalBinary([]byte{i}); err != nil {
+			t.Fatal(err)
+		}
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Card
+		if err := back.UnmarshalText(text); err != nil || back != c {
+			t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, back, err, c)
+		}
+		if b, err := back.MarshalBinary(); err != nil || b[0] != i {
+			t.Errorf("MarshalBinary(%s) = %v, %v, want %d", text, b, err, i)
+		}
+	}
+
+	for _, bad := range []string{"", "A", "Asx", "1s", "Ax", "♠A"} {
+		var c Card
+		if err := c.UnmarshalText([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalText(%q) = %v, want an error", bad, c)
+		}
+	}
+	var c Card
+	if err := c.UnmarshalText([]byte("tH")); err != nil || c != (Card{Ten, Heart}) {
+		t.Errorf("UnmarshalText(tH) = %v, %v, want Ten of Hearts", c, err)
+	}
+	if err := c.UnmarshalBinary([]byte{53}); err == nil {
+		t.Errorf("UnmarshalBinary(53) = %v, want an error", c)
+	}
+	if _, err := (Card{}).MarshalText(); err == nil {
+		t.Error("MarshalText of the zero card succeeded, want an error")
+	}
+}
+
+func TestCard_jokerJSON(t *testing.T) {
+	cards := []Card{{Ace, Spade}, joker}
+	data, err := json.Marshal(cards)
+	if err != nil || string(data) != `["As","Jk"]` {
+		t.Errorf("json.Marshal() = %s, %v, want [\"As\",\"Jk\"]", data, err)
+	}
+	var back []Card
+	if err := json.Unmarshal(data, &back); err != nil || len(back) != 2 || back[1] != joker {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", data, back, err)
+	}
+}
+
+func TestHandRank_text(t *testing.T) {
+	for r := HighCard; r <= StraightFlush; r++ {
+		text, err := r.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back HandRank
+		if err := back.UnmarshalText(text); err != nil || back != r {
+			t.Errorf("UnmarshalText(%s) = %v, %v", text, back, err)
+		}
+	}
+	var r HandRank
+	if err := r.UnmarshalText([]byte("Boat")); err == nil {
+		t.Error("UnmarshalText(Boat) succeeded, want an error")
+	}
+}
+
+func TestHand_encoding(t *testing.T) {
+	tests := []struct {
+		text string
+		json string
+	}{
+		{"As Ah Ad Tc Ts", `{"cards":["As","Ah","Ad","Tc","Ts"],"category":"FullHouse","ranks":["A","T"]}`},
+		{"5c 4d 3h 2s Ac", `{"cards":["Ac","5c","4d","3h","2s"],"category":"Straight","ranks":["5","4","3","2","A"]}`},
+		{"Kd Qd 9d 6d 2d", `{"cards":["Kd","Qd","9d","6d","2d"],"category":"Flush","ranks":["K","Q","9","6","2"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var h Hand
+			if err := h.UnmarshalText([]byte(tt.text)); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := json.Marshal(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.json)
+			}
+			var fromJSON Hand
+			if err := json.Unmarshal(data, &fromJSON); err != nil {
+				t.Fatal(err)
+			}
+			if compare(&fromJSON, &h) != 0 || fromJSON.cards != h.cards {
+				t.Errorf("JSON round trip = %v, want %v", &fromJSON, &h)
+			}
+
+			bin, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromBinary Hand
+			if err := fromBinary.UnmarshalBinary(bin); err != nil {
+				t.Fatal(err)
+			}
+			if compare(&fromBinary, &h) != 0 || fromBinary.cards != h.cards {
+				t.Errorf("binary round trip = %v, want %v", &fromBinary, &h)
+			}
+
+			text, err := fromBinary.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromText Hand
+			if err := fromText.UnmarshalText(text); err != nil || compare(&fromText, &h) != 0 {
+				t.Errorf("text round trip of %s = %v, %v", text, &fromText, err)
+			}
+		})
+	}
+
+	for _, bad := range []string{
+		`{"cards":["As","Ah","Ad","Tc"],"category":"ThreeOfAKind"}`,
+		`{"cards":["As","As","Ad","Tc","Ts"],"category":"FullHouse"}`,
+		`{"cards":["As","Ah","Ad","Tc","Ts"],"category":"Flush"}`,
+		`{"cards":["As","Ah","Ad","Tc","Tx"],"category":"FullHouse"}`,
+		`{"cards":["As","Ah","Ad","Tc","Ts"],"category":"FullHouse","ranks":["T","A"]}`,
+		`{"cards":["As","Ah","Ad","Tc","Ts"],"category":"FullHouse"}`,
+	} {
+		var h Hand
+		if err := json.Unmarshal([]byte(bad), &h); err == nil {
+			t.Errorf("json.Unmarshal(%s) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestHand_MarshalJSON_dealt(t *testing.T) {
+	// dealt hands are scored when they are played, not when they are dealt
+	hands, err := dealWith(rand.New(rand.NewSource(7)), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range hands {
+		data, err := json.Marshal(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Hand
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Fatalf("json.Unmarshal(%s) = %v", data, err)
+		}
+		scored, _ := handOf(h.cards[:])
+		if compare(&back, scored) != 0 || back.cards != scored.cards {
+			t.Errorf("JSON round trip of %s = %v, want %v", formatCards(h.cards[:]), &back, scored)
+		}
+	}
+}
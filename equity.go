@@ -0,0 +1,191 @@
+package poker
+
+//go:generate go test -run TestPreflopEquity -update -timeout 2h
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// preflopEquityFile holds the heads-up all-in equity of every class against
+// every other, one line per class in startingHands order.
+//
+//go:embed preflop_equity.txt
+var preflopEquityFile string
+
+var (
+	preflopOnce   sync.Once
+	preflopMatrix *equityMatrix
+)
+
+// preflopEquity returns the embedded equity table.
+func preflopEquity() *equityMatrix {
+	preflopOnce.Do(func() {
+		m, err := readEquity(strings.NewReader(preflopEquityFile))
+		if err != nil {
+			panic(err)
+		}
+		preflopMatrix = m
+	})
+	return preflopMatrix
+}
+
+// classOf returns the class of two hole cards.
+func classOf(a, b Card) StartingHand {
+	if a.rank < b.rank {
+		a, b = b, a
+	}
+	return StartingHand{high: a.rank, low: b.rank, suited: a.suit == b.suit && a.rank != b.rank}
+}
+
+// cardBit gives every card its own bit, in deck order.
+func cardBit(c Card) uint64 {
+	return 1 << (int(c.suit)*13 + int(c.rank) - 2)
+}
+
+// canonicalBoards returns one board for every five-card board up to a
+// change of suits, with how many boards it stands for. Two boards are the
+// same up to suits when their suit masks are the same once sorted.
+func canonicalBoards() map[[4]uint16]int64 {
+	boards := make(map[[4]uint16]int64)
+	cards := deck()
+	var idx [5]int
+	var rec func(k, from int)
+	rec = func(k, from int) {
+		if k == 5 {
+			var m suitMasks
+			for _, i := range idx {
+				m.add(cards[i])
+			}
+			key := [4]uint16(m)
+			sort.Slice(key[:], func(i, j int) bool { return key[i] > key[j] })
+			boards[key]++
+			return
+		}
+		for i := from; i < 52; i++ {
+			idx[k] = i
+			rec(k+1, i+1)
+		}
+	}
+	rec(0, 0)
+
+	return boards
+}
+
+// enumerateMatrix works out every matchup exactly by showing down every pair
+// of hole cards on every board. Boards the same up to suits are dealt once
+// and counted as often as they come up, which leaves the totals for each
+// pair of classes unchanged.
+func enumerateMatrix() *equityMatrix {
+	m := newEquityMatrix()
+	type combo struct {
+		cards [2]Card
+		bits  uint64
+		class int
+	}
+	combos := make([]combo, 0, 1326)
+	for i, h := range startingHands() {
+		for _, cards := range h.holeCards() {
+			combos = append(combos, combo{cards: cards, bits: cardBit(cards[0]) | cardBit(cards[1]), class: i})
+		}
+	}
+
+	var won, tied [169][169]int64
+	values := make([]handValue, len(combos))
+	live := make([]int, 0, len(combos))
+	for key, n := range canonicalBoards() {
+		board := suitMasks(key)
+		var bits uint64
+		for s, mask := range key {
+			bits |= uint64(mask) << (13 * s)
+		}
+		live = live[:0]
+		for i, c := range combos {
+			if c.bits&bits != 0 {
+				continue
+			}
+			hand := board
+			hand.add(c.cards[0])
+			hand.add(c.cards[1])
+			values[i] = hand.value()
+			live = append(live, i)
+		}
+		for x, i := range live {
+			a := &combos[i]
+			for _, j := range live[x+1:] {
+				b := &combos[j]
+				if a.bits&b.bits != 0 {
+					continue
+				}
+				switch {
+				case values[i] > values[j]:
+					won[a.class][b.class] += n
+				case values[i] < values[j]:
+					won[b.class][a.class] += n
+				default:
+					tied[a.class][b.class] += n
+					tied[b.class][a.class] += n
+				}
+			}
+		}
+	}
+
+	for i := range won {
+		for j := range won[i] {
+			if total := won[i][j] + won[j][i] + tied[i][j]; total > 0 {
+				m.equity[i][j] = (float64(won[i][j]) + float64(tied[i][j])/2) / float64(total)
+			}
+		}
+	}
+
+	return m
+}
+
+// writeEquity writes the table one class per line: its name and its equity
+// against every class in startingHands order.
+func writeEquity(w io.Writer, m *equityMatrix) error {
+	bw := bufio.NewWriter(w)
+	for i, h := range startingHands() {
+		fmt.Fprint(bw, h)
+		for _, e := range m.equity[i] {
+			fmt.Fprintf(bw, " %.6f", e)
+		}
+		fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
+
+func readEquity(r io.Reader) (*equityMatrix, error) {
+	m := newEquityMatrix()
+	hands := startingHands()
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), 1<<16)
+	i := 0
+	for ; sc.Scan(); i++ {
+		fields := strings.Fields(sc.Text())
+		if i >= len(hands) || len(fields) != len(hands)+1 || fields[0] != hands[i].String() {
+			return nil, fmt.Errorf("equity table line %d: want %d equities for a class in order", i+1, len(hands))
+		}
+		for j, f := range fields[1:] {
+			e, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("equity table line %d: %w", i+1, err)
+			}
+			m.equity[i][j] = e
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if i != len(hands) {
+		return nil, fmt.Errorf("equity table has %d classes, want %d", i, len(hands))
+	}
+
+	return m, nil
+}
@@ -0,0 +1,106 @@
+package poker
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate the preflop equity table")
+
+func TestPreflopEquity(t *testing.T) {
+	if *update {
+		f, err := os.Create("preflop_equity.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := writeEquity(f, enumerateMatrix()); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	m := preflopEquity()
+	hand := func(s string) int {
+		for i, h := range startingHands() {
+			if h.String() == s {
+				return i
+			}
+		}
+		t.Fatalf("no class %s", s)
+		return -1
+	}
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"AA", "KK", 0.8195},
+		{"AKs", "QQ", 0.4605},
+		{"AKo", "22", 0.4735},
+		{"AA", "72o", 0.8820},
+		{"KK", "KK", 0.5},
+		{"T9s", "T9s", 0.5},
+	}
+	for _, tt := range tests {
+		if got := m.equity[hand(tt.a)][hand(tt.b)]; math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("equity %s against %s = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+	}
+	for i := range m.equity {
+		for j := range m.equity[i] {
+			if m.weight[i][j] > 0 && math.Abs(m.equity[i][j]+m.equity[j][i]-1) > 2e-6 {
+				t.Fatalf("equities %d-%d and %d-%d add up to %v", i, j, j, i, m.equity[i][j]+m.equity[j][i])
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 5; k++ {
+		i, j := r.Intn(169), r.Intn(169)
+		if i == j {
+			continue
+		}
+		hands := startingHands()
+		want := sampleMatchup(r, hands[i].holeCards(), hands[j].holeCards(), 50000)
+		if got := m.equity[i][j]; math.Abs(got-want) > 0.01 {
+			t.Errorf("equity %s against %s = %.4f, sampled %.4f", hands[i], hands[j], got, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeEquity(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != preflopEquityFile {
+		t.Errorf("writeEquity() does not reproduce the embedded table")
+	}
+	if _, err := readEquity(bytes.NewReader(buf.Bytes()[:buf.Len()/2])); err == nil {
+		t.Errorf("readEquity() of half a table error = nil")
+	}
+}
+
+func Test_classOf(t *testing.T) {
+	counts := make(map[StartingHand]int)
+	cards := deck()
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			h := classOf(cards[i], cards[j])
+			if h != classOf(cards[j], cards[i]) {
+				t.Errorf("classOf depends on the order of %v and %v", cards[i], cards[j])
+			}
+			counts[h]++
+		}
+	}
+	if len(counts) != 169 {
+		t.Errorf("%d classes, want 169", len(counts))
+	}
+	for h, n := range counts {
+		if n != h.combos() {
+			t.Errorf("%s dealt %d ways, want %d", h, n, h.combos())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package poker
+
+import "math/bits"
+
+// handValue packs a category and its tie-break ranks into one number that
+// orders hands the same way compare does: four bits for the category
+// followed by four bits for each of the five ranks.
+type handValue uint32
+
+func packValue(r HandRank, ranks [5]Rank) handValue {
+	v := handValue(r)
+	for _, rk := range ranks {
+		v = v<<4 | handValue(rk)
+	}
+	return v
+}
+
+// valueOf packs a scored hand.
+func valueOf(h *Hand) handValue {
+	return packValue(h.handRank, h.ranks)
+}
+
+func (v handValue) handRank() HandRank {
+	return HandRank(v >> 20)
+}
+
+func (v handValue) ranks() [5]Rank {
+	var ranks [5]Rank
+	for i := range ranks {
+		ranks[i] = Rank(v >> (16 - 4*i) & 0xf)
+	}
+	return ranks
+}
+
+// suitMasks holds one bit per rank for each suit, with bit 0 for a deuce.
+type suitMasks [4]uint16
+
+func (m *suitMasks) add(c Card) {
+	m[c.suit] |= 1 << (c.rank - 2)
+}
+
+func masksOf(cards []Card) suitMasks {
+	var m suitMasks
+	for _, c := range cards {
+		m.add(c)
+	}
+	return m
+}
+
+// evaluate returns the value of the best five-card hand in up to seven
+// cards.
+func evaluate(cards []Card) handValue {
+	return masksOf(cards).value()
+}
+
+// topRanks takes the n highest ranks of a mask.
+func topRanks(mask uint16, n int, into []Rank) {
+	for i := 0; i < n && mask != 0; i++ {
+		b := 15 - bits.LeadingZeros16(mask)
+		into[i] = Rank(b + 2)
+		mask &^= 1 << b
+	}
+}
+
+// straightHigh returns the top rank of the highest straight in a mask, or
+// zero. A wheel tops out at five.
+func straightHigh(mask uint16) Rank {
+	run := mask & (mask << 1) & (mask << 2) & (mask << 3) & (mask << 4)
+	if run != 0 {
+		return Rank(15 - bits.LeadingZeros16(run) + 2)
+	}
+	const wheel = 1<<12 | 0xf
+	if mask&wheel == wheel {
+		return Five
+	}
+	return 0
+}
+
+func straightRanks(high Rank) [5]Rank {
+	return [5]Rank{high, high - 1, high - 2, high - 3, high - 4}
+}
+
+func (m suitMasks) value() handValue {
+	var ranks [5]Rank
+	for _, s := range m {
+		if bits.OnesCount16(s) < 5 {
+			continue
+		}
+		if high := straightHigh(s); high != 0 {
+			return packValue(StraightFlush, straightRanks(high))
+		}
+		break
+	}
+
+	r1 := m[0] | m[1] | m[2] | m[3]
+	r2 := m[0]&m[1] | m[0]&m[2] | m[0]&m[3] | m[1]&m[2] | m[1]&m[3] | m[2]&m[3]
+	r3 := m[0]&m[1]&m[2] | m[0]&m[1]&m[3] | m[0]&m[2]&m[3] | m[1]&m[2]&m[3]
+	r4 := m[0] & m[1] & m[2] & m[3]
+
+	if r4 != 0 {
+		topRanks(r4, 1, ranks[:1])
+		topRanks(r1&^(1<<(ranks[0]-2)), 1, ranks[1:2])
+		return packValue(FourOfAKind, ranks)
+	}
+	if r3 != 0 {
+		topRanks(r3, 1, ranks[:1])
+		if pairs := r2 &^ (1 << (ranks[0] - 2)); pairs != 0 {
+			topRanks(pairs, 1, ranks[1:2])
+			return packValue(FullHouse, ranks)
+		}
+	}
+	for _, s := range m {
+		if bits.OnesCount16(s) >= 5 {
+			topRanks(s, 5, ranks[:])
+			return packValue(Flush, ranks)
+		}
+	}
+	if high := straightHigh(r1); high != 0 {
+		return packValue(Straight, straightRanks(high))
+	}
+	if r3 != 0 {
+		topRanks(r1&^(1<<(ranks[0]-2)), 2, ranks[1:3])
+		return packValue(ThreeOfAKind, ranks)
+	}
+	if bits.OnesCount16(r2) >= 2 {
+		topRanks(r2, 2, ranks[:2])
+		topRanks(r1&^(1<<(ranks[0]-2)|1<<(ranks[1]-2)), 1, ranks[2:3])
+		return packValue(TwoPair, ranks)
+	}
+	if r2 != 0 {
+		topRanks(r2, 1, ranks[:1])
+		topRanks(r1&^(1<<(ranks[0]-2)), 3, ranks[1:4])
+		return packValue(Pair, ranks)
+	}
+	topRanks(r1, 5, ranks[:])
+
+	return packValue(HighCard, ranks)
+}
@@ -0,0 +1,77 @@
+package poker
+
+import (
+	"testing"
+)
+
+func Test_evaluate(t *testing.T) {
+	cards := deck()
+	var idx [5]int
+	for idx[0] = 0; idx[0] < 52; idx[0]++ {
+		for idx[1] = idx[0] + 1; idx[1] < 52; idx[1]++ {
+			for idx[2] = idx[1] + 1; idx[2] < 52; idx[2]++ {
+				for idx[3] = idx[2] + 1; idx[3] < 52; idx[3]++ {
+					for idx[4] = idx[3] + 1; idx[4] < 52; idx[4]++ {
+						h := Hand{cards: [5]Card{cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]]}}
+						got := evaluate(h.cards[:])
+						h.score()
+						if want := valueOf(&h); got != want {
+							t.Fatalf("evaluate(%s) = %s %v, want %s %v", h.String(), got.handRank(), got.ranks(), h.handRank, h.ranks)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_evaluateSeven(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     []Card
+		wantScore HandRank
+		wantRanks [5]Rank
+	}{
+		{
+			name:      "two trips make a full house",
+			cards:     []Card{{Nine, Spade}, {Nine, Heart}, {Nine, Club}, {Four, Spade}, {Four, Heart}, {Four, Club}, {Ace, Diamond}},
+			wantScore: FullHouse,
+			wantRanks: [5]Rank{Nine, Four},
+		},
+		{
+			name:      "three pairs play the best two",
+			cards:     []Card{{Nine, Spade}, {Nine, Heart}, {Six, Club}, {Six, Spade}, {Four, Heart}, {Four, Club}, {Two, Diamond}},
+			wantScore: TwoPair,
+			wantRanks: [5]Rank{Nine, Six, Four},
+		},
+		{
+			name:      "straight flush over quads",
+			cards:     []Card{{Two, Spade}, {Three, Spade}, {Four, Spade}, {Five, Spade}, {Ace, Spade}, {Ace, Heart}, {Ace, Club}},
+			wantScore: StraightFlush,
+			wantRanks: [5]Rank{Five, Four, Three, Two, Rank(1)},
+		},
+		{
+			name:      "six card flush",
+			cards:     []Card{{Two, Heart}, {Nine, Heart}, {Four, Heart}, {Jack, Heart}, {King, Heart}, {Queen, Heart}, {Ace, Club}},
+			wantScore: Flush,
+			wantRanks: [5]Rank{King, Queen, Jack, Nine, Four},
+		},
+		{
+			name:      "six high straight over the wheel",
+			cards:     []Card{{Two, Heart}, {Three, Club}, {Four, Heart}, {Five, Spade}, {Six, Heart}, {Ace, Diamond}, {Ace, Club}},
+			wantScore: Straight,
+			wantRanks: [5]Rank{Six, Five, Four, Three, Two},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := evaluate(tt.cards)
+			if got := v.handRank(); got != tt.wantScore {
+				t.Errorf("evaluate() = %v, want %v", got, tt.wantScore)
+			}
+			if got := v.ranks(); got != tt.wantRanks {
+				t.Errorf("evaluate() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+}
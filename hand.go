@@ -28,7 +28,7 @@ func (h *Hand) flush() bool {
 
 func (h *Hand) straight() bool {
 	rank := h.cards[0].rank
-	if rank == 14 && h.cards[1].rank == 5 {
+	if h.wheel() {
 		h.ranks = [5]Rank{5, 4, 3, 2, 1}
 		h.handRank = Straight
 		return true
@@ -45,6 +45,11 @@ func (h *Hand) straight() bool {
 	return true
 }
 
+func (h *Hand) wheel() bool {
+	return h.cards[0].rank == Ace && h.cards[1].rank == Five && h.cards[2].rank == Four &&
+		h.cards[3].rank == Three && h.cards[4].rank == Two
+}
+
 func (h *Hand) kind() HandRank {
 	h.count = make(map[Rank]int)
 	for _, c := range h.cards {
@@ -180,10 +185,26 @@ func (h *Hand) score() {
 	case s:
 		h.handRank = Straight
 	default:
+		h.ranks = [5]Rank{}
 		h.handRank = h.kind()
 	}
 }
 
+// compare orders two scored hands: it returns a positive number if a beats b,
+// a negative number if b beats a and zero on a split.
+func compare(a, b *Hand) int {
+	if a.handRank != b.handRank {
+		return int(a.handRank) - int(b.handRank)
+	}
+	for i, r := range a.ranks {
+		if r != b.ranks[i] {
+			return int(r) - int(b.ranks[i])
+		}
+	}
+
+	return 0
+}
+
 func (h *Hand) sort() {
 	sort.SliceStable(h.cards[:], func(i, j int) bool {
 		return h.cards[i].rank > h.cards[j].rank
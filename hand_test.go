@@ -350,6 +350,17 @@ func TestHand_straight(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "ace and five without a wheel",
+			cards: [5]Card{
+				{rank: Ace, suit: Spade},
+				{rank: Five, suit: Diamond},
+				{rank: Five, suit: Heart},
+				{rank: Two, suit: Club},
+				{rank: Two, suit: Spade},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,335 @@
+package poker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Action is one thing a player did in a hand: an ante or blind, or a move in
+// a betting round.
+type Action struct {
+	Street Street     `json:"street"`
+	Seat   int        `json:"seat"`
+	Kind   ActionKind `json:"kind"`
+	// Amount is the chips the action put in the pot.
+	Amount int `json:"amount,omitempty"`
+	// To is the seat's whole bet for the street after a bet or raise.
+	To    int  `json:"to,omitempty"`
+	AllIn bool `json:"allIn,omitempty"`
+}
+
+// post reports whether the kind is an ante, a blind or a bring-in.
+func (k ActionKind) post() bool {
+	return k <= PostBringIn
+}
+
+// SeatRecord is a player dealt into a hand, their stack before the antes
+// and blinds, and the cards they were dealt in the order they came. Hands
+// imported from a site only know the cards that were shown; up holds the
+// face-up stud cards of players whose hands were not.
+type SeatRecord struct {
+	Seat  int    `json:"seat"`
+	Name  string `json:"name"`
+	Stack int    `json:"stack"`
+	Cards []Card `json:"cards,omitempty"`
+	Up    []Card `json:"up,omitempty"`
+}
+
+// ShowdownRecord is a hand shown down and what it made.
+type ShowdownRecord struct {
+	Seat        int      `json:"seat"`
+	Category    HandRank `json:"category"`
+	Description string   `json:"description"`
+}
+
+// Award is what a seat took from the pot.
+type Award struct {
+	Seat   int `json:"seat"`
+	Amount int `json:"amount"`
+}
+
+// PotRecord is the main pot or a side pot, the rake taken from it and what
+// each seat won from it.
+type PotRecord struct {
+	Amount int     `json:"amount"`
+	Rake   int     `json:"rake,omitempty"`
+	Awards []Award `json:"awards"`
+}
+
+// TournamentRecord places a hand in a tournament.
+type TournamentRecord struct {
+	ID    int64  `json:"id"`
+	BuyIn string `json:"buyIn,omitempty"`
+	Level string `json:"level,omitempty"`
+}
+
+// HandHistory records a hand from the deal to the awards. The seed shuffles
+// the deck, so together with the actions it is enough to play the hand
+// again exactly.
+//
+// Amounts are in chips, or in cents when there is a currency. Stud hands
+// keep the small and big bets in Small and Big and have no button.
+type HandHistory struct {
+	Game       string            `json:"game"`
+	Limit      string            `json:"limit,omitempty"`
+	Number     int               `json:"number"`
+	Seed       int64             `json:"seed"`
+	Table      string            `json:"table,omitempty"`
+	Time       string            `json:"time,omitempty"`
+	Currency   string            `json:"currency,omitempty"`
+	Tournament *TournamentRecord `json:"tournament,omitempty"`
+	TableSize  int               `json:"tableSize"`
+	Small      int               `json:"small"`
+	Big        int               `json:"big"`
+	Ante       int               `json:"ante,omitempty"`
+	Button     int               `json:"button"`
+	// Hero is the player the hand was recorded for, whose cards are known
+	// without being shown.
+	Hero     string           `json:"hero,omitempty"`
+	Seats    []SeatRecord     `json:"seats"`
+	Board    []Card           `json:"board,omitempty"`
+	Actions  []Action         `json:"actions"`
+	Showdown []ShowdownRecord `json:"showdown,omitempty"`
+	Awards   []Award          `json:"awards"`
+	// Pots split the awards by pot, the main pot first, when the hand
+	// records them.
+	Pots []PotRecord `json:"pots,omitempty"`
+}
+
+// Games a hand history can record.
+const (
+	fiveCardGame = "five-card"
+	holdemGame   = "holdem"
+	omahaGame    = "omaha"
+	studGame     = "stud"
+)
+
+// newHistory starts the history of a hand whose antes and blinds are in.
+func newHistory(game string, t *Table, h *TableHand, seed int64) *HandHistory {
+	hh := &HandHistory{
+		Game:      game,
+		Number:    h.number,
+		Seed:      seed,
+		TableSize: len(t.seats),
+		Small:     t.small,
+		Big:       t.big,
+		Ante:      t.ante,
+		Button:    h.button,
+		Actions:   append([]Action(nil), h.posts...),
+	}
+	for _, s := range h.seats {
+		p := t.seats[s]
+		hh.Seats = append(hh.Seats, SeatRecord{Seat: s, Name: p.name, Stack: p.stack + h.paid[s]})
+	}
+	return hh
+}
+
+func (hh *HandHistory) seat(s int) *SeatRecord {
+	for i := range hh.Seats {
+		if hh.Seats[i].Seat == s {
+			return &hh.Seats[i]
+		}
+	}
+	return nil
+}
+
+// streets returns the first street of the hand and the last one dealt.
+func (hh *HandHistory) streets() (first, last Street) {
+	stud := hh.Game == studGame
+	if stud {
+		first = ThirdStreet
+	}
+	last = first
+	for _, a := range hh.Actions {
+		if a.Street > last {
+			last = a.Street
+		}
+	}
+	switch {
+	case stud && len(hh.Showdown) > 0:
+		last = SeventhStreet
+	case !stud && len(hh.Board) >= 3:
+		last = Flop + Street(len(hh.Board)-3)
+	}
+	return first, last
+}
+
+// award records what each seat won from each pot and in all, in the order
+// they were dealt in.
+func (hh *HandHistory) award(h *TableHand, pots []sidePot) {
+	won := make(map[int]int)
+	for _, pot := range pots {
+		rec := PotRecord{Amount: pot.amount, Awards: make([]Award, 0)}
+		for _, s := range h.seats {
+			if pot.won[s] > 0 {
+				rec.Awards = append(rec.Awards, Award{Seat: s, Amount: pot.won[s]})
+				won[s] += pot.won[s]
+			}
+		}
+		hh.Pots = append(hh.Pots, rec)
+	}
+	for _, s := range h.seats {
+		if won[s] > 0 {
+			hh.Awards = append(hh.Awards, Award{Seat: s, Amount: won[s]})
+		}
+	}
+}
+
+// enumText writes an enum by name and reads it back by trying every value
+// up to last.
+func enumText[T ~int](v T, last T, name func(T) string) ([]byte, error) {
+	if v < 0 || v > last {
+		return nil, fmt.Errorf("invalid %T %d", v, int(v))
+	}
+	return []byte(name(v)), nil
+}
+
+func parseEnum[T ~int](text []byte, last T, name func(T) string) (T, error) {
+	for v := T(0); v <= last; v++ {
+		if name(v) == string(text) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %T %q", T(0), text)
+}
+
+func (s Street) MarshalText() ([]byte, error) {
+	return enumText(s, SeventhStreet, Street.String)
+}
+
+func (s *Street) UnmarshalText(text []byte) (err error) {
+	*s, err = parseEnum(text, SeventhStreet, Street.String)
+	return err
+}
+
+func (k ActionKind) MarshalText() ([]byte, error) {
+	return enumText(k, Raise, ActionKind.String)
+}
+
+func (k *ActionKind) UnmarshalText(text []byte) (err error) {
+	*k, err = parseEnum(text, Raise, ActionKind.String)
+	return err
+}
+
+// historyLog writes hand histories as JSON lines.
+type historyLog struct {
+	enc *json.Encoder
+}
+
+func newHistoryLog(w io.Writer) *historyLog {
+	return &historyLog{enc: json.NewEncoder(w)}
+}
+
+func (l *historyLog) write(hh *HandHistory) error {
+	if l == nil {
+		return nil
+	}
+	return l.enc.Encode(hh)
+}
+
+// readHistories reads hand histories written as JSON lines.
+func readHistories(r io.Reader) ([]*HandHistory, error) {
+	histories := make([]*HandHistory, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		hh := &HandHistory{}
+		if err := json.Unmarshal(scanner.Bytes(), hh); err != nil {
+			return nil, fmt.Errorf("hand history line %d: %w", line, err)
+		}
+		histories = append(histories, hh)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// scriptedStrategy plays recorded actions back in order.
+type scriptedStrategy struct {
+	actions []Action
+}
+
+func (s *scriptedStrategy) act(d decision) (ActionKind, int) {
+	if len(s.actions) == 0 || s.actions[0].Seat != d.seat {
+		return -1, 0
+	}
+	a := s.actions[0]
+	s.actions = s.actions[1:]
+	return a.Kind, a.To
+}
+
+// replay plays a recorded hand again from its stacks, posts, seed and
+// actions, and returns an error unless it comes out the same.
+func replay(hh *HandHistory) error {
+	t := &Table{seats: make([]*Player, hh.TableSize), small: hh.Small, big: hh.Big, ante: hh.Ante}
+	h := &TableHand{number: hh.Number, button: hh.Button, small: -1, big: -1, bets: make(map[int]int), paid: make(map[int]int)}
+	for _, seat := range hh.Seats {
+		if seat.Seat < 0 || seat.Seat >= len(t.seats) {
+			return fmt.Errorf("hand %d: no seat %d", hh.Number, seat.Seat)
+		}
+		t.seats[seat.Seat] = &Player{name: seat.Name, stack: seat.Stack}
+		h.seats = append(h.seats, seat.Seat)
+	}
+	n := 0
+	for ; n < len(hh.Actions) && hh.Actions[n].Kind.post(); n++ {
+		a := hh.Actions[n]
+		if a.Seat < 0 || a.Seat >= len(t.seats) || t.seats[a.Seat] == nil {
+			return fmt.Errorf("hand %d: no player in seat %d", hh.Number, a.Seat)
+		}
+		switch a.Kind {
+		case PostSmallBlind:
+			h.small = a.Seat
+		case PostBigBlind:
+			if h.big < 0 {
+				h.big = a.Seat
+			}
+		}
+		h.post(t, a.Seat, a.Amount, a.Kind)
+	}
+
+	var got *HandHistory
+	var err error
+	switch hh.Game {
+	case fiveCardGame:
+		got, err = playShowdown(t, h, hh.Seed)
+	case holdemGame:
+		got, err = playHoldem(t, h, hh.Seed, &scriptedStrategy{actions: hh.Actions[n:]})
+	default:
+		return fmt.Errorf("hand %d: unknown game %q", hh.Number, hh.Game)
+	}
+	if err != nil {
+		return fmt.Errorf("hand %d: %w", hh.Number, err)
+	}
+	got.Limit, got.Table, got.Time, got.Currency, got.Tournament, got.Hero =
+		hh.Limit, hh.Table, hh.Time, hh.Currency, hh.Tournament, hh.Hero
+
+	want, err := json.Marshal(hh)
+	if err != nil {
+		return err
+	}
+	replayed, err := json.Marshal(got)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(want, replayed) {
+		return fmt.Errorf("hand %d does not replay: got %s", hh.Number, replayed)
+	}
+	return nil
+}
+
+// handSeed draws the seed that shuffles the next hand, from r or from the
+// global source when r is nil.
+func handSeed(r *rand.Rand) int64 {
+	if r == nil {
+		return rand.Int63()
+	}
+	return r.Int63()
+}
@@ -0,0 +1,298 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// decision is what a player sees when it is their turn to act.
+type decision struct {
+	seat   int
+	street Street
+	hole   []Card
+	board  []Card
+	pot    int
+	// bet is the seat's bet this street and level the largest bet.
+	bet   int
+	level int
+	stack int
+	// minRaise is the smallest whole bet a raise, or a first bet, can make;
+	// a player with less can still go all in. canRaise is false once the
+	// betting is closed to the seat, after a short all-in or when nobody
+	// else has chips.
+	minRaise int
+	canRaise bool
+}
+
+// Strategy picks a player's action. A bet or raise also names the seat's
+// whole bet for the street.
+type Strategy interface {
+	act(d decision) (kind ActionKind, to int)
+}
+
+// passiveStrategy checks when it can and calls otherwise.
+type passiveStrategy struct{}
+
+func (passiveStrategy) act(d decision) (ActionKind, int) {
+	if d.bet < d.level {
+		return Call, 0
+	}
+	return Check, 0
+}
+
+// randomStrategy picks legal actions at random, for exercising the engine.
+type randomStrategy struct {
+	rng *rand.Rand
+}
+
+func (s randomStrategy) act(d decision) (ActionKind, int) {
+	if d.canRaise && s.rng.Intn(5) == 0 {
+		top := d.bet + d.stack
+		to := top
+		if d.minRaise < top {
+			to = d.minRaise + s.rng.Intn(top-d.minRaise+1)
+		}
+		if d.level == 0 {
+			return Bet, to
+		}
+		return Raise, to
+	}
+	if d.bet < d.level {
+		if s.rng.Intn(3) == 0 {
+			return Fold, 0
+		}
+		return Call, 0
+	}
+	return Check, 0
+}
+
+// holdemEngine deals no-limit Texas hold'em and asks the strategy for every
+// action. Each hand is shuffled from a seed drawn from rng and its history
+// goes to log when there is one.
+type holdemEngine struct {
+	rng      *rand.Rand
+	strategy Strategy
+	log      *historyLog
+}
+
+func (e holdemEngine) play(t *Table, h *TableHand) error {
+	hh, err := playHoldem(t, h, handSeed(e.rng), e.strategy)
+	if err != nil {
+		return err
+	}
+	return e.log.write(hh)
+}
+
+// holdemHand is a hand of hold'em in progress.
+type holdemHand struct {
+	t        *Table
+	h        *TableHand
+	hh       *HandHistory
+	strategy Strategy
+	hole     map[int][]Card
+	board    []Card
+	folded   map[int]bool
+}
+
+// live returns the seats that have not folded, in the order they act.
+func (g *holdemHand) live() []int {
+	live := make([]int, 0, len(g.h.seats))
+	for _, s := range g.h.seats {
+		if !g.folded[s] {
+			live = append(live, s)
+		}
+	}
+	return live
+}
+
+// playHoldem deals the hole cards and the board from the seed, plays the
+// betting rounds and pays the pots. Cards go round from the first seat left
+// of the button.
+func playHoldem(t *Table, h *TableHand, seed int64, strategy Strategy) (*HandHistory, error) {
+	g := &holdemHand{
+		t:        t,
+		h:        h,
+		hh:       newHistory(holdemGame, t, h, seed),
+		strategy: strategy,
+		hole:     make(map[int][]Card),
+		folded:   make(map[int]bool),
+	}
+	hole, board, err := dealHoldem(seed, len(h.seats))
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range h.seats {
+		g.hole[s] = hole[i]
+		g.hh.seat(s).Cards = g.hole[s]
+	}
+
+	first := 0
+	for i, s := range h.seats {
+		if s == h.big {
+			first = (i + 1) % len(h.seats)
+		}
+	}
+	if err := g.betting(Preflop, first); err != nil {
+		return nil, err
+	}
+	for street, dealt := Flop, 3; street <= River && len(g.live()) > 1; street, dealt = street+1, dealt+1 {
+		g.board = board[:dealt]
+		g.hh.Board = g.board
+		h.bets = make(map[int]int)
+		if err := g.betting(street, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	g.showdown()
+	return g.hh, nil
+}
+
+// dealHoldem shuffles a deck from the seed and deals n players two hole
+// cards each, going round twice, and the five board cards after them.
+func dealHoldem(seed int64, n int) ([][]Card, []Card, error) {
+	cards := deck()
+	if 2*n+5 > len(cards) {
+		return nil, nil, fmt.Errorf("not enough cards in the deck")
+	}
+	shuffleWith(rand.New(rand.NewSource(seed)), cards)
+	hole := make([][]Card, n)
+	for i := range hole {
+		hole[i] = []Card{cards[i], cards[n+i]}
+	}
+	return hole, cards[2*n : 2*n+5], nil
+}
+
+// betting plays a betting round starting with the given position among the
+// seats dealt in. The round ends once every player left with chips has
+// acted and matched the largest bet, or all but one have folded.
+func (g *holdemHand) betting(street Street, first int) error {
+	t, h := g.t, g.h
+	level := 0
+	for _, b := range h.bets {
+		if b > level {
+			level = b
+		}
+	}
+	minRaise := t.big
+	// raises counts full raises; a seat may raise again only if there has
+	// been one since it last acted
+	raises := 0
+	acted := make(map[int]int)
+
+	others := func(seat int) bool {
+		for _, s := range g.live() {
+			if s != seat && t.seats[s].stack > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	due := func(s int) bool {
+		if g.folded[s] || t.seats[s].stack == 0 {
+			return false
+		}
+		if h.bets[s] < level {
+			return true
+		}
+		_, done := acted[s]
+		return !done && others(s)
+	}
+
+	for i := first; len(g.live()) > 1; i = (i + 1) % len(h.seats) {
+		s := h.seats[i]
+		if !due(s) {
+			if g.settled(due) {
+				return nil
+			}
+			continue
+		}
+		p := t.seats[s]
+		last, done := acted[s]
+		d := decision{
+			seat:     s,
+			street:   street,
+			hole:     g.hole[s],
+			board:    g.board,
+			pot:      h.pot(),
+			bet:      h.bets[s],
+			level:    level,
+			stack:    p.stack,
+			minRaise: level + minRaise,
+			canRaise: (!done || last < raises) && p.stack > level-h.bets[s] && others(s),
+		}
+		if level == 0 {
+			d.minRaise = t.big
+		}
+		kind, to := g.strategy.act(d)
+		a := Action{Street: street, Seat: s, Kind: kind}
+
+		switch kind {
+		case Fold:
+			g.folded[s] = true
+		case Check:
+			if d.bet < level {
+				return fmt.Errorf("seat %d cannot check facing a bet of %d", s, level)
+			}
+		case Call:
+			if d.bet >= level {
+				return fmt.Errorf("seat %d has no bet to call", s)
+			}
+			a.Amount = h.put(t, s, level-d.bet, true)
+		case Bet, Raise:
+			switch {
+			case kind == Bet && level > 0:
+				return fmt.Errorf("seat %d cannot bet facing a bet of %d, only raise", s, level)
+			case kind == Raise && level == 0:
+				return fmt.Errorf("seat %d cannot raise with no bet, only bet", s)
+			case !d.canRaise:
+				return fmt.Errorf("seat %d cannot raise now", s)
+			case to > d.bet+d.stack:
+				return fmt.Errorf("seat %d cannot bet %d with %d", s, to, d.bet+d.stack)
+			case to < d.minRaise && to < d.bet+d.stack:
+				return fmt.Errorf("seat %d must bet at least %d, not %d", s, d.minRaise, to)
+			}
+			if to-level >= minRaise {
+				minRaise = to - level
+				raises++
+			}
+			level = to
+			a.Amount = h.put(t, s, to-d.bet, true)
+			a.To = to
+		default:
+			return fmt.Errorf("seat %d made no valid action", s)
+		}
+		a.AllIn = p.stack == 0
+		g.hh.Actions = append(g.hh.Actions, a)
+		acted[s] = raises
+	}
+
+	return nil
+}
+
+// settled reports whether nobody is left to act.
+func (g *holdemHand) settled(due func(int) bool) bool {
+	for _, s := range g.h.seats {
+		if due(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// showdown pays the pots: all of it to the last player left, or by the best
+// hands among those still in.
+func (g *holdemHand) showdown() {
+	live := g.live()
+	if len(live) == 1 {
+		g.hh.award(g.h, g.h.award(g.t, live, func(int) handValue { return 0 }))
+		return
+	}
+	values := make(map[int]handValue)
+	for _, s := range live {
+		v := evaluate(append(append([]Card(nil), g.hole[s]...), g.board...))
+		values[s] = v
+		g.hh.Showdown = append(g.hh.Showdown, ShowdownRecord{Seat: s, Category: v.handRank(), Description: v.describe()})
+	}
+	g.hh.award(g.h, g.h.award(g.t, live, func(s int) handValue { return values[s] }))
+}
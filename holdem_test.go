@@ -0,0 +1,251 @@
+package poker
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func holdemTable(t *testing.T, stacks ...int) *Table {
+	t.Helper()
+	table := newTable(len(stacks), 5, 10, 0, DeadButton)
+	for i, stack := range stacks {
+		if err := table.sit(i, &Player{name: string(rune('A' + i)), stack: stack}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return table
+}
+
+// recordHoldem plays hold'em hands one at a time and returns their histories
+// with the stacks each left behind.
+func recordHoldem(t *testing.T, seed int64, hands int) ([]*HandHistory, []map[int]int) {
+	t.Helper()
+	table := holdemTable(t, 1000, 400, 1000, 250)
+	table.ante = 1
+	rng := rand.New(rand.NewSource(seed))
+	var log strings.Builder
+	engine := holdemEngine{rng: rng, strategy: randomStrategy{rng: rng}, log: newHistoryLog(&log)}
+	var stacks []map[int]int
+	for i := 0; i < hands && table.players() > 1; i++ {
+		if err := table.playHands(1, engine); err != nil {
+			t.Fatal(err)
+		}
+		after := make(map[int]int)
+		for s, p := range table.seats {
+			if p != nil {
+				after[s] = p.stack
+			}
+		}
+		stacks = append(stacks, after)
+	}
+	histories, err := readHistories(strings.NewReader(log.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return histories, stacks
+}
+
+func chips(t *Table) int {
+	n := 0
+	for _, p := range t.seats {
+		if p != nil {
+			n += p.stack
+		}
+	}
+	return n
+}
+
+func TestHoldemEngine(t *testing.T) {
+	table := holdemTable(t, 1000, 400, 1000, 250, 1000, 600)
+	var log bytes.Buffer
+	rng := rand.New(rand.NewSource(7))
+	engine := holdemEngine{rng: rng, strategy: randomStrategy{rng: rand.New(rand.NewSource(8))}, log: newHistoryLog(&log)}
+	total := chips(table)
+	for i := 0; i < 200 && table.players() > 1; i++ {
+		if err := table.playHands(1, engine); err != nil {
+			t.Fatal(err)
+		}
+		if got := chips(table); got != total {
+			t.Fatalf("hand %d: %d chips on the table, want %d", i+1, got, total)
+		}
+	}
+
+	histories, err := readHistories(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histories) == 0 {
+		t.Fatal("no hands recorded")
+	}
+	showdowns, allIns := 0, 0
+	for _, hh := range histories {
+		if len(hh.Showdown) > 0 {
+			showdowns++
+		}
+		for _, a := range hh.Actions {
+			if a.AllIn {
+				allIns++
+				break
+			}
+		}
+		in, out := 0, 0
+		for _, a := range hh.Actions {
+			in += a.Amount
+		}
+		for _, a := range hh.Awards {
+			out += a.Amount
+		}
+		if in != out {
+			t.Errorf("hand %d: %d chips went in, %d were awarded", hh.Number, in, out)
+		}
+		pots := 0
+		for _, pot := range hh.Pots {
+			won := 0
+			for _, a := range pot.Awards {
+				won += a.Amount
+			}
+			if won != pot.Amount {
+				t.Errorf("hand %d: %d awarded from a pot of %d", hh.Number, won, pot.Amount)
+			}
+			pots += pot.Amount
+		}
+		if pots != in {
+			t.Errorf("hand %d: %d chips went in, the pots hold %d", hh.Number, in, pots)
+		}
+		if err := replay(hh); err != nil {
+			t.Error(err)
+		}
+	}
+	if showdowns == 0 || allIns == 0 {
+		t.Errorf("%d showdowns and %d all-in hands in %d, want some of each", showdowns, allIns, len(histories))
+	}
+}
+
+func TestHoldemEngine_passive(t *testing.T) {
+	table := holdemTable(t, 100, 100, 100)
+	h, err := table.startHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hh, err := playHoldem(table, h, 42, passiveStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the button calls, the small blind completes, the big blind checks
+	// its option and everyone checks down
+	want := []string{
+		"Preflop 1 PostSmallBlind 5", "Preflop 2 PostBigBlind 10",
+		"Preflop 0 Call 10", "Preflop 1 Call 5", "Preflop 2 Check 0",
+		"Flop 1 Check 0", "Flop 2 Check 0", "Flop 0 Check 0",
+		"Turn 1 Check 0", "Turn 2 Check 0", "Turn 0 Check 0",
+		"River 1 Check 0", "River 2 Check 0", "River 0 Check 0",
+	}
+	got := make([]string, len(hh.Actions))
+	for i, a := range hh.Actions {
+		got[i] = fmt.Sprintf("%s %d %s %d", a.Street, a.Seat, a.Kind, a.Amount)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("actions =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(hh.Board) != 5 || len(hh.Showdown) != 3 {
+		t.Errorf("board %v and %d shown down, want 5 cards and 3 hands", hh.Board, len(hh.Showdown))
+	}
+	if err := replay(hh); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHoldemEngine_illegal(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []Action
+		want    string
+	}{
+		{"check facing a bet", []Action{{Seat: 0, Kind: Check}}, "seat 0 cannot check facing a bet of 10"},
+		{"bet facing a bet", []Action{{Seat: 0, Kind: Bet, To: 30}}, "only raise"},
+		{"raise too small", []Action{{Seat: 0, Kind: Raise, To: 15}}, "seat 0 must bet at least 20, not 15"},
+		{"raise beyond the stack", []Action{{Seat: 0, Kind: Raise, To: 500}}, "seat 0 cannot bet 500 with 100"},
+		{"nothing to call", []Action{{Seat: 0, Kind: Call}, {Seat: 1, Kind: Call}, {Seat: 2, Kind: Call}}, "seat 2 has no bet to call"},
+		{"out of turn", []Action{{Seat: 1, Kind: Fold}}, "seat 0 made no valid action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := holdemTable(t, 100, 100, 100)
+			h, err := table.startHand()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = playHoldem(table, h, 1, &scriptedStrategy{actions: tt.actions})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("playHoldem() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replay(t *testing.T) {
+	table := holdemTable(t, 100, 100, 100)
+	h, err := table.startHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy := &scriptedStrategy{actions: []Action{
+		{Seat: 0, Kind: Raise, To: 30}, {Seat: 1, Kind: Fold}, {Seat: 2, Kind: Raise, To: 100},
+		{Seat: 0, Kind: Call},
+	}}
+	hh, err := playHoldem(table, h, 99, strategy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hh.Board) != 5 || len(hh.Showdown) != 2 || !hh.Actions[len(hh.Actions)-1].AllIn {
+		t.Fatalf("history = %+v, want both all in and shown down", hh)
+	}
+	if err := replay(hh); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := newHistoryLog(&buf).write(hh); err != nil {
+		t.Fatal(err)
+	}
+	read, err := readHistories(&buf)
+	if err != nil || len(read) != 1 {
+		t.Fatalf("readHistories() = %v, %v", read, err)
+	}
+	if err := replay(read[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	read[0].Seed++
+	if err := replay(read[0]); err == nil {
+		t.Error("replay with another seed succeeded, want an error")
+	}
+}
+
+func TestShowdownEngine_history(t *testing.T) {
+	table := holdemTable(t, 100, 100, 100, 100)
+	table.ante = 1
+	var log bytes.Buffer
+	engine := showdownEngine{rng: rand.New(rand.NewSource(3)), log: newHistoryLog(&log)}
+	if err := table.playHands(5, engine); err != nil {
+		t.Fatal(err)
+	}
+	histories, err := readHistories(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histories) != 5 {
+		t.Fatalf("%d hands recorded, want 5", len(histories))
+	}
+	for _, hh := range histories {
+		if len(hh.Seats) != 4 || len(hh.Seats[0].Cards) != 5 || len(hh.Showdown) != 4 {
+			t.Errorf("hand %d = %+v, want four five-card hands shown down", hh.Number, hh)
+		}
+		if err := replay(hh); err != nil {
+			t.Error(err)
+		}
+	}
+}
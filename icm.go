@@ -0,0 +1,355 @@
+package poker
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+// icmExactStates caps the finishing orders icm works through exactly. Past
+// it, or past 64 players, the places are sampled instead.
+const icmExactStates = 1 << 18
+
+// icmSamples is how many finishing orders icm samples for a large field.
+const icmSamples = 20000
+
+// icm returns each player's share of the prizes by the Independent Chip
+// Model: a player finishes first with a chance in proportion to their chips,
+// and the others finish the places below the same way. Players with no chips
+// split the places left once everyone else is placed.
+//
+// The model is exact while the players who could take the places above each
+// paid one are few enough to count. A large field, such as a multi-table
+// tournament with many places paid, is estimated from sampled finishing
+// orders.
+func icm(stacks []int, prizes []float64) []float64 {
+	places := len(prizes)
+	if places > len(stacks) {
+		places = len(stacks)
+	}
+	if len(stacks) <= 64 && icmStates(len(stacks), places) <= icmExactStates {
+		return icmExact(stacks, prizes[:places])
+	}
+	return icmSampled(stacks, prizes[:places], icmSamples)
+}
+
+// icmStates returns how many sets of players could take the places above
+// the last one paid, or more than icmExactStates once it passes that.
+func icmStates(players, places int) int {
+	states, subsets := 0, 1
+	for k := 0; k < places; k++ {
+		states += subsets
+		if states > icmExactStates {
+			return states
+		}
+		// subsets of k+1 players out of players, from the subsets of k
+		subsets = subsets * (players - k) / (k + 1)
+	}
+	return states
+}
+
+// icmExact works through every set of players that could take the places
+// above each one paid.
+func icmExact(stacks []int, prizes []float64) []float64 {
+	equity := make([]float64, len(stacks))
+	total := 0
+	for _, s := range stacks {
+		total += s
+	}
+
+	// layer holds the chance that exactly the players in each mask took the
+	// places above the one being paid.
+	layer := map[uint64]float64{0: 1}
+	for place := range prizes {
+		next := make(map[uint64]float64)
+		for mask, p := range layer {
+			left := total
+			for i, s := range stacks {
+				if mask&(1<<i) != 0 {
+					left -= s
+				}
+			}
+			players := len(stacks) - bits.OnesCount64(mask)
+			for i, s := range stacks {
+				if mask&(1<<i) != 0 {
+					continue
+				}
+				q := float64(s) / float64(left)
+				if left == 0 {
+					q = 1 / float64(players)
+				}
+				if q == 0 {
+					continue
+				}
+				equity[i] += p * q * prizes[place]
+				next[mask|1<<i] += p * q
+			}
+		}
+		layer = next
+	}
+
+	return equity
+}
+
+// icmSampled averages the prizes over sampled finishing orders. Each player
+// draws an exponential time to finish first, scaled down by their chips, and
+// the players finish in order of time; players with no chips finish last in
+// a random order. The sampler is seeded the same way every time, so equities
+// for nearby stacks are compared over the same draws.
+func icmSampled(stacks []int, prizes []float64, samples int) []float64 {
+	rng := rand.New(rand.NewSource(1))
+	equity := make([]float64, len(stacks))
+	times := make([]float64, len(stacks))
+	ties := make([]float64, len(stacks))
+	order := make([]int, len(stacks))
+	for n := 0; n < samples; n++ {
+		for i, s := range stacks {
+			order[i] = i
+			times[i], ties[i] = math.Inf(1), rng.Float64()
+			if s > 0 {
+				times[i] = rng.ExpFloat64() / float64(s)
+			}
+		}
+		sort.Slice(order, func(i, j int) bool {
+			a, b := order[i], order[j]
+			if times[a] != times[b] {
+				return times[a] < times[b]
+			}
+			return ties[a] < ties[b]
+		})
+		for place, prize := range prizes {
+			equity[order[place]] += prize
+		}
+	}
+	for i := range equity {
+		equity[i] /= float64(samples)
+	}
+
+	return equity
+}
+
+// split rounds shares of total to whole amounts that add up to it, giving
+// the chips lost to rounding to the largest remainders.
+func split(shares []float64, total int) []int {
+	amounts := make([]int, len(shares))
+	order := make([]int, len(shares))
+	paid := 0
+	for i, s := range shares {
+		amounts[i] = int(s)
+		paid += amounts[i]
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return shares[order[i]]-float64(amounts[order[i]]) > shares[order[j]]-float64(amounts[order[j]])
+	})
+	for i := 0; paid < total && len(order) > 0; i = (i + 1) % len(order) {
+		amounts[order[i]]++
+		paid++
+	}
+
+	return amounts
+}
+
+// icmChop deals the prizes left by ICM equity.
+func icmChop(stacks []int, prizes []int) []int {
+	p := make([]float64, len(prizes))
+	total := 0
+	for i, n := range prizes {
+		p[i] = float64(n)
+		total += n
+	}
+
+	return split(icm(stacks, p), total)
+}
+
+// chipChop locks up the lowest prize left for every player and splits the
+// rest of the prizes by chip count.
+func chipChop(stacks []int, prizes []int) []int {
+	total, chips := 0, 0
+	for _, n := range prizes {
+		total += n
+	}
+	for _, s := range stacks {
+		chips += s
+	}
+	floor := 0
+	if len(prizes) >= len(stacks) {
+		floor = prizes[len(stacks)-1]
+	}
+
+	shares := make([]float64, len(stacks))
+	rest := total - floor*len(stacks)
+	for i, s := range stacks {
+		shares[i] = float64(floor) + float64(rest)*float64(s)/float64(chips)
+	}
+
+	return split(shares, total)
+}
+
+// pushSpot is a player deciding whether to move all in before the flop when
+// everyone else has folded to them but one player left to act.
+type pushSpot struct {
+	// stacks are the chips each player has behind and posted the chips they
+	// have put in the pot as blinds and antes.
+	stacks  []int
+	posted  []int
+	hero    int
+	villain int
+	// prizes are the prizes for the places still to be decided.
+	prizes []float64
+}
+
+// after returns the hero's ICM equity once the hand plays out: the hero
+// folds, takes the pot uncalled, or wins or loses all in.
+func (s pushSpot) after(fold, called, won bool) float64 {
+	stacks := make([]int, len(s.stacks))
+	copy(stacks, s.stacks)
+	pot := 0
+	for _, p := range s.posted {
+		pot += p
+	}
+	h, v := s.hero, s.villain
+	heroIn, villainIn := stacks[h]+s.posted[h], stacks[v]+s.posted[v]
+
+	switch {
+	case fold:
+		stacks[v] += pot
+	case !called:
+		stacks[h] += pot
+	default:
+		matched := heroIn
+		if villainIn < matched {
+			matched = villainIn
+		}
+		dead := pot - s.posted[h] - s.posted[v]
+		stacks[h], stacks[v] = heroIn-matched, villainIn-matched
+		if won {
+			stacks[h] += 2*matched + dead
+		} else {
+			stacks[v] += 2*matched + dead
+		}
+	}
+
+	return icm(stacks, s.prizes)[h]
+}
+
+// pushEV returns the hero's ICM equity moving all in with hand against a
+// player who calls with the given classes.
+func (s pushSpot) pushEV(hand StartingHand, calls []StartingHand, equity equityFunc) float64 {
+	combos := 0
+	for _, c := range calls {
+		combos += c.combos()
+	}
+	if combos == 0 {
+		return s.after(false, false, false)
+	}
+	call := float64(combos) / 1326
+	eq := equity(hand, calls)
+
+	return (1-call)*s.after(false, false, false) +
+		call*(eq*s.after(false, true, true)+(1-eq)*s.after(false, true, false))
+}
+
+// pushFoldTable returns the classes the hero gains prize equity moving all in
+// with, against a player who calls with the given classes.
+func pushFoldTable(s pushSpot, calls []StartingHand, equity equityFunc) map[StartingHand]bool {
+	fold := s.after(true, false, false)
+	push := make(map[StartingHand]bool)
+	for _, hand := range startingHands() {
+		if s.pushEV(hand, calls, equity) > fold {
+			push[hand] = true
+		}
+	}
+
+	return push
+}
+
+// remainingPrizes returns the prizes for the places still to be decided,
+// first place first.
+func (t *Tournament) remainingPrizes() []int {
+	prizes := payoutAmounts(t.pool, t.payoutFractions())
+	left := make([]int, t.remaining())
+	copy(left, prizes)
+	return left
+}
+
+// stillIn returns the players still in, in seating order table by table.
+func (t *Tournament) stillIn() []*Player {
+	players := make([]*Player, 0, t.remaining())
+	for _, table := range t.tables {
+		for _, p := range table.seats {
+			if _, out := t.places[p]; p != nil && !out {
+				players = append(players, p)
+			}
+		}
+	}
+	return players
+}
+
+// prizeEquity returns what each player still in is worth by ICM.
+func (t *Tournament) prizeEquity() map[*Player]float64 {
+	players := t.stillIn()
+	stacks := make([]int, len(players))
+	for i, p := range players {
+		stacks[i] = p.stack
+	}
+	prizes := make([]float64, 0)
+	for _, n := range t.remainingPrizes() {
+		prizes = append(prizes, float64(n))
+	}
+
+	equity := make(map[*Player]float64)
+	for i, e := range icm(stacks, prizes) {
+		equity[players[i]] = e
+	}
+	return equity
+}
+
+// chop ends the tournament with a deal, such as icmChop or chipChop, among
+// the players still in. They finish in order of chips.
+func (t *Tournament) chop(deal func(stacks []int, prizes []int) []int) {
+	players := t.stillIn()
+	sort.SliceStable(players, func(i, j int) bool { return players[i].stack > players[j].stack })
+	stacks := make([]int, len(players))
+	for i, p := range players {
+		stacks[i] = p.stack
+	}
+	amounts := deal(stacks, t.remainingPrizes())
+	for i, p := range players {
+		t.dealt[p] = amounts[i]
+	}
+	for i, p := range players {
+		t.places[p] = i + 1
+	}
+}
+
+// pushSpot sets up the decision for the player in seat hero of a hand at one
+// of the tournament's tables, against the player in seat villain. The
+// players at the other tables count toward ICM with their stacks as they
+// are.
+func (t *Tournament) pushSpot(table *Table, h *TableHand, hero, villain int) pushSpot {
+	spot := pushSpot{hero: -1, villain: -1}
+	for _, p := range t.stillIn() {
+		posted := 0
+		for s, seated := range table.seats {
+			if seated != p {
+				continue
+			}
+			posted = h.paid[s]
+			switch s {
+			case hero:
+				spot.hero = len(spot.stacks)
+			case villain:
+				spot.villain = len(spot.stacks)
+			}
+		}
+		spot.stacks = append(spot.stacks, p.stack)
+		spot.posted = append(spot.posted, posted)
+	}
+	for _, n := range t.remainingPrizes() {
+		spot.prizes = append(spot.prizes, float64(n))
+	}
+
+	return spot
+}
@@ -0,0 +1,171 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func Test_icm(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		prizes []float64
+		want   []float64
+	}{
+		{"heads up", []int{3000, 1000}, []float64{70, 30}, []float64{60, 40}},
+		{"even stacks", []int{500, 500, 500}, []float64{50, 30, 20}, []float64{100. / 3, 100. / 3, 100. / 3}},
+		{"three handed", []int{50, 30, 20}, []float64{50, 30, 20}, []float64{38.3929, 32.75, 28.8571}},
+		{"winner takes all", []int{600, 300, 100}, []float64{100}, []float64{60, 30, 10}},
+		{"no chips", []int{400, 0, 600}, []float64{50, 30, 20}, []float64{38, 20, 42}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, got := range icm(tt.stacks, tt.prizes) {
+				if math.Abs(got-tt.want[i]) > 1e-3 {
+					t.Errorf("icm()[%d] = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_icm_largeField(t *testing.T) {
+	// past 32 players the exact model needs wider masks than uint32
+	stacks := make([]int, 40)
+	for i := range stacks {
+		stacks[i] = 1000
+	}
+	stacks[39] = 4000
+	prizes := []float64{50, 30, 20}
+	got := icm(stacks, prizes)
+	for i := 1; i < 39; i++ {
+		if math.Abs(got[i]-got[0]) > 1e-9 {
+			t.Errorf("icm()[%d] = %v, want %v like the other even stacks", i, got[i], got[0])
+		}
+	}
+	if got[39] <= got[0] {
+		t.Errorf("icm()[39] = %v, want more than %v for the biggest stack", got[39], got[0])
+	}
+	if sum := got[0]*39 + got[39]; math.Abs(sum-100) > 1e-6 {
+		t.Errorf("icm() sums to %v, want 100", sum)
+	}
+
+	// too many places to count: sampled
+	stacks = make([]int, 100)
+	prizes = make([]float64, 15)
+	for i := range stacks {
+		stacks[i] = 1000 + 100*i
+	}
+	for i := range prizes {
+		prizes[i] = float64(15 - i)
+	}
+	if icmStates(len(stacks), len(prizes)) <= icmExactStates {
+		t.Fatal("100 players with 15 places paid fit the exact model")
+	}
+	got = icm(stacks, prizes)
+	sum := 0.
+	for _, e := range got {
+		sum += e
+	}
+	if got[99] <= 2*got[0] {
+		t.Errorf("icm()[99] = %v, want well over icm()[0] = %v", got[99], got[0])
+	}
+	if math.Abs(sum-120) > 1e-6 {
+		t.Errorf("icm() sums to %v, want 120", sum)
+	}
+}
+
+func Test_icmSampled(t *testing.T) {
+	stacks := []int{50, 30, 20, 0}
+	prizes := []float64{50, 30, 20}
+	want := icmExact(stacks, prizes)
+	for i, got := range icmSampled(stacks, prizes, icmSamples) {
+		if math.Abs(got-want[i]) > 0.5 {
+			t.Errorf("icmSampled()[%d] = %v, want about %v", i, got, want[i])
+		}
+	}
+}
+
+func Test_chop(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	prizes := []int{500, 300, 200}
+	if got := chipChop(stacks, prizes); got[0] != 400 || got[1] != 320 || got[2] != 280 {
+		t.Errorf("chipChop() = %v, want [400 320 280]", got)
+	}
+	if got := chipChop([]int{1, 1, 1}, prizes); got[0]+got[1]+got[2] != 1000 || got[0]-got[2] > 1 {
+		t.Errorf("chipChop() = %v, want an even split of 1000", got)
+	}
+	got := icmChop(stacks, prizes)
+	if got[0]+got[1]+got[2] != 1000 || got[0] != 384 || got[1] != 327 || got[2] != 289 {
+		t.Errorf("icmChop() = %v, want [384 327 289]", got)
+	}
+}
+
+func Test_pushFoldTable(t *testing.T) {
+	spot := pushSpot{
+		stacks:  []int{1950, 3900, 2000, 2000},
+		posted:  []int{50, 100, 0, 0},
+		hero:    0,
+		villain: 1,
+		prizes:  []float64{50, 30, 20},
+	}
+	equity := preflopEquity().against
+	calls := startingHands()[:20]
+	bubble := pushFoldTable(spot, calls, equity)
+	spot.prizes = []float64{100}
+	chips := pushFoldTable(spot, calls, equity)
+
+	for hand := range bubble {
+		if !chips[hand] {
+			t.Errorf("%s pushes on the bubble but not for chips", hand)
+		}
+	}
+	aces := StartingHand{high: Ace, low: Ace}
+	trash := StartingHand{high: Seven, low: Two}
+	if !bubble[aces] || bubble[trash] {
+		t.Errorf("bubble pushes AA %v and 72o %v, want AA only", bubble[aces], bubble[trash])
+	}
+	if len(bubble) >= len(chips) {
+		t.Errorf("bubble pushes %d hands, want fewer than the %d for chips", len(bubble), len(chips))
+	}
+}
+
+func TestTournament_chop(t *testing.T) {
+	clock := &manualClock{}
+	tour := newTournament(6, 100, 1000, testLevels, clock)
+	for _, name := range []string{"Ann", "Bob", "Cid", "Dee", "Eve", "Fay"} {
+		tour.register(name)
+	}
+	engine := tickingEngine{
+		HandEngine: showdownEngine{rng: rand.New(rand.NewSource(1))},
+		clock:      clock,
+		tick:       time.Minute,
+	}
+	for tour.remaining() > 3 {
+		if err := tour.playHand(engine); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total := 0.0
+	for _, e := range tour.prizeEquity() {
+		total += e
+	}
+	if math.Abs(total-600) > 1e-6 {
+		t.Errorf("prize equity adds up to %v, want 600", total)
+	}
+
+	tour.chop(icmChop)
+	if tour.remaining() != 0 {
+		t.Errorf("remaining() = %d after the chop", tour.remaining())
+	}
+	prizes := 0
+	for _, s := range tour.standings() {
+		prizes += s.prize
+	}
+	if prizes != 600 {
+		t.Errorf("prizes = %d, want 600", prizes)
+	}
+}
@@ -0,0 +1,341 @@
+package poker
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// maxRounds is the most betting rounds a hand indexer deals cards for.
+const maxRounds = 4
+
+// suitCounts is how many cards of one suit each round deals.
+type suitCounts [maxRounds]int
+
+func (a suitCounts) less(b suitCounts) bool {
+	for r := range a {
+		if a[r] != b[r] {
+			return a[r] < b[r]
+		}
+	}
+	return false
+}
+
+// suitConfig is the counts of every suit, largest first. Hands that are the
+// same up to a change of suits share a configuration.
+type suitConfig [4]suitCounts
+
+// handIndexer numbers hands dealt over betting rounds, such as two hole
+// cards then a three-card flop, so that hands the same up to a change of
+// suits share a number and the numbers run from zero without gaps.
+//
+// A hand is indexed by its configuration and then, for each group of suits
+// with the same counts, the multiset of which ranks those suits were dealt
+// in each round.
+type handIndexer struct {
+	rounds  []int
+	configs []suitConfig
+	// offsets holds the first index of each configuration and, last, the
+	// number of hands.
+	offsets []int64
+}
+
+func newHandIndexer(rounds ...int) *handIndexer {
+	h := &handIndexer{rounds: rounds}
+	var config suitConfig
+	var rec func(suit int)
+	rec = func(suit int) {
+		if suit == 4 {
+			for r, n := range rounds {
+				total := 0
+				for _, c := range config {
+					total += c[r]
+				}
+				if total != n {
+					return
+				}
+			}
+			h.configs = append(h.configs, config)
+			return
+		}
+		var counts func(r int)
+		counts = func(r int) {
+			if r == len(rounds) {
+				if suit == 0 || !config[suit-1].less(config[suit]) {
+					rec(suit + 1)
+				}
+				return
+			}
+			for n := 0; n <= rounds[r]; n++ {
+				config[suit][r] = n
+				counts(r + 1)
+			}
+			config[suit][r] = 0
+		}
+		counts(0)
+	}
+	rec(0)
+
+	h.offsets = make([]int64, len(h.configs)+1)
+	for i, c := range h.configs {
+		h.offsets[i+1] = h.offsets[i] + configSize(c)
+	}
+
+	return h
+}
+
+var (
+	preflopIndexer = newHandIndexer(2)
+	flopIndexer    = newHandIndexer(2, 3)
+	turnIndexer    = newHandIndexer(2, 3, 1)
+	riverIndexer   = newHandIndexer(2, 3, 1, 1)
+)
+
+// size returns how many hands there are up to a change of suits.
+func (h *handIndexer) size() int64 {
+	return h.offsets[len(h.offsets)-1]
+}
+
+// choose returns n choose k for any n.
+func choose(n, k int) int64 {
+	if k < 0 || n < k {
+		return 0
+	}
+	c := int64(1)
+	for i := 1; i <= k; i++ {
+		c = c * int64(n-k+i) / int64(i)
+	}
+	return c
+}
+
+// statesOf returns how many ways one suit can be dealt the given counts.
+func statesOf(c suitCounts) int64 {
+	n := int64(1)
+	left := 13
+	for _, k := range c {
+		n *= choose(left, k)
+		left -= k
+	}
+	return n
+}
+
+// groups splits a configuration into runs of suits with equal counts.
+func groups(c suitConfig) [][2]int {
+	runs := make([][2]int, 0, 4)
+	for s := 0; s < 4; {
+		e := s + 1
+		for e < 4 && c[e] == c[s] {
+			e++
+		}
+		runs = append(runs, [2]int{s, e})
+		s = e
+	}
+	return runs
+}
+
+// configSize returns how many hands share a configuration: for each group of
+// g suits with n ways to deal each, the multisets of g states.
+func configSize(c suitConfig) int64 {
+	size := int64(1)
+	for _, g := range groups(c) {
+		n := int(statesOf(c[g[0]]))
+		size *= choose(n+g[1]-g[0]-1, g[1]-g[0])
+	}
+	return size
+}
+
+// stateIndex numbers the ranks one suit was dealt in each round. Each round
+// picks its ranks from those the suit has not been dealt yet.
+func stateIndex(masks []uint16, c suitCounts) int64 {
+	idx := int64(0)
+	used := uint16(0)
+	left := 13
+	for r, m := range masks {
+		n := int64(0)
+		i := 1
+		for rest := m; rest != 0; rest &= rest - 1 {
+			b := bits.TrailingZeros16(rest)
+			// position among the ranks not yet used
+			p := b - bits.OnesCount16(used&(1<<b-1))
+			n += choose(p, i)
+			i++
+		}
+		idx = idx*choose(left, c[r]) + n
+		used |= m
+		left -= c[r]
+	}
+	return idx
+}
+
+// stateMasks turns a state number back into the ranks dealt each round.
+func stateMasks(idx int64, c suitCounts, rounds int) []uint16 {
+	radix := make([]int64, rounds)
+	left := 13
+	for r := 0; r < rounds; r++ {
+		radix[r] = choose(left, c[r])
+		left -= c[r]
+	}
+	subsets := make([]int64, rounds)
+	for r := rounds - 1; r >= 0; r-- {
+		subsets[r] = idx % radix[r]
+		idx /= radix[r]
+	}
+
+	masks := make([]uint16, rounds)
+	used := uint16(0)
+	for r := 0; r < rounds; r++ {
+		n := subsets[r]
+		for i := c[r]; i >= 1; i-- {
+			p := i - 1
+			for choose(p+1, i) <= n {
+				p++
+			}
+			n -= choose(p, i)
+			// the p-th rank not yet used
+			for b := 0; b < 13; b++ {
+				if used&(1<<b) == 0 {
+					if p == 0 {
+						masks[r] |= 1 << b
+						break
+					}
+					p--
+				}
+			}
+		}
+		used |= masks[r]
+	}
+	return masks
+}
+
+// multisetIndex numbers a sorted multiset of states by turning it into a set
+// of distinct numbers and ranking that.
+func multisetIndex(states []int64) int64 {
+	idx := int64(0)
+	for i, s := range states {
+		idx += choose(int(s)+i, i+1)
+	}
+	return idx
+}
+
+func multisetStates(idx int64, g int) []int64 {
+	states := make([]int64, g)
+	for i := g; i >= 1; i-- {
+		lo, hi := int64(i-1), int64(i)
+		for choose(int(hi), i) <= idx {
+			hi *= 2
+		}
+		// largest b with choose(b, i) <= idx
+		for lo+1 < hi {
+			mid := (lo + hi) / 2
+			if choose(int(mid), i) <= idx {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		idx -= choose(int(lo), i)
+		states[i-1] = lo - int64(i-1)
+	}
+	return states
+}
+
+// suitHand is one suit of a hand: its counts, its ranks each round and its
+// state number.
+type suitHand struct {
+	counts suitCounts
+	masks  [maxRounds]uint16
+	state  int64
+}
+
+func (a *suitHand) before(b *suitHand) bool {
+	if a.counts != b.counts {
+		return b.counts.less(a.counts)
+	}
+	return a.state < b.state
+}
+
+// index returns the number of a hand given as the cards of each round in
+// order.
+func (h *handIndexer) index(cards []Card) int64 {
+	var suits [4]suitHand
+	n := 0
+	for r, k := range h.rounds {
+		for _, c := range cards[n : n+k] {
+			suits[c.suit].counts[r]++
+			suits[c.suit].masks[r] |= 1 << (c.rank - 2)
+		}
+		n += k
+	}
+	for s := range suits {
+		suits[s].state = stateIndex(suits[s].masks[:len(h.rounds)], suits[s].counts)
+	}
+	for i := 1; i < len(suits); i++ {
+		for j := i; j > 0 && suits[j].before(&suits[j-1]); j-- {
+			suits[j], suits[j-1] = suits[j-1], suits[j]
+		}
+	}
+
+	var config suitConfig
+	for s := range suits {
+		config[s] = suits[s].counts
+	}
+	ci := sort.Search(len(h.configs), func(i int) bool { return !h.configs[i].before(config) })
+
+	idx := int64(0)
+	var buf [4]int64
+	for _, g := range groups(config) {
+		states := buf[:0]
+		for _, s := range suits[g[0]:g[1]] {
+			states = append(states, s.state)
+		}
+		n := int(statesOf(config[g[0]]))
+		idx = idx*choose(n+g[1]-g[0]-1, g[1]-g[0]) + multisetIndex(states)
+	}
+
+	return h.offsets[ci] + idx
+}
+
+// before orders configurations the way newHandIndexer lists them.
+func (a suitConfig) before(b suitConfig) bool {
+	for s := range a {
+		if a[s] != b[s] {
+			return a[s].less(b[s])
+		}
+	}
+	return false
+}
+
+// unindex returns the canonical hand with the given number, the cards of
+// each round in order. Suits are handed out from spades in configuration
+// order.
+func (h *handIndexer) unindex(idx int64) []Card {
+	ci := sort.Search(len(h.configs), func(i int) bool { return h.offsets[i+1] > idx })
+	config := h.configs[ci]
+	idx -= h.offsets[ci]
+
+	runs := groups(config)
+	radix := make([]int64, len(runs))
+	for i, g := range runs {
+		n := int(statesOf(config[g[0]]))
+		radix[i] = choose(n+g[1]-g[0]-1, g[1]-g[0])
+	}
+	masks := make([][]uint16, 4)
+	for i := len(runs) - 1; i >= 0; i-- {
+		g := runs[i]
+		states := multisetStates(idx%radix[i], g[1]-g[0])
+		idx /= radix[i]
+		for k, st := range states {
+			masks[g[0]+k] = stateMasks(st, config[g[0]], len(h.rounds))
+		}
+	}
+
+	cards := make([]Card, 0, 7)
+	for r := range h.rounds {
+		for s := range masks {
+			for m := masks[s][r]; m != 0; m &= m - 1 {
+				cards = append(cards, Card{rank: Rank(bits.TrailingZeros16(m) + 2), suit: Suit(s)})
+			}
+		}
+	}
+
+	return cards
+}
@@ -0,0 +1,162 @@
+package poker
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHandIndexer_size(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexer *handIndexer
+		want    int64
+	}{
+		{"preflop", preflopIndexer, 169},
+		{"flop", flopIndexer, 1286792},
+		{"turn", turnIndexer, 55190538},
+		{"river", riverIndexer, 2428287420},
+	}
+	for _, tt := range tests {
+		if got := tt.indexer.size(); got != tt.want {
+			t.Errorf("%s size() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// suitPermutations lists the 24 ways to relabel the suits.
+func suitPermutations() [][4]Suit {
+	perms := make([][4]Suit, 0, 24)
+	var p [4]Suit
+	var rec func(k int, used int)
+	rec = func(k int, used int) {
+		if k == 4 {
+			perms = append(perms, p)
+			return
+		}
+		for s := Spade; s <= Heart; s++ {
+			if used&(1<<s) == 0 {
+				p[k] = s
+				rec(k+1, used|1<<s)
+			}
+		}
+	}
+	rec(0, 0)
+	return perms
+}
+
+// canonical sorts the ranks each suit was dealt round by round, so two hands
+// the same up to suits have the same canonical form.
+func canonical(rounds []int, cards []Card) [4][maxRounds]uint16 {
+	var suits [4][maxRounds]uint16
+	n := 0
+	for r, k := range rounds {
+		for _, c := range cards[n : n+k] {
+			suits[c.suit][r] |= 1 << (c.rank - 2)
+		}
+		n += k
+	}
+	sort.Slice(suits[:], func(i, j int) bool {
+		for r := range suits[i] {
+			if suits[i][r] != suits[j][r] {
+				return suits[i][r] < suits[j][r]
+			}
+		}
+		return false
+	})
+	return suits
+}
+
+// enumerateRounds calls f with every hand dealt over the rounds, the cards of
+// each round in order.
+func enumerateRounds(rounds []int, f func(cards []Card)) {
+	cards := deck()
+	total := 0
+	for _, k := range rounds {
+		total += k
+	}
+	hand := make([]Card, total)
+	used := make([]bool, 52)
+	var rec func(r, k, from, n int)
+	rec = func(r, k, from, n int) {
+		if r == len(rounds) {
+			f(hand)
+			return
+		}
+		if k == rounds[r] {
+			rec(r+1, 0, 0, n)
+			return
+		}
+		for i := from; i < 52; i++ {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			hand[n] = cards[i]
+			rec(r, k+1, i+1, n+1)
+			used[i] = false
+		}
+	}
+	rec(0, 0, 0, 0)
+}
+
+func TestHandIndexer_bijection(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexer *handIndexer
+		long    bool
+	}{
+		{"preflop", preflopIndexer, false},
+		{"flop", flopIndexer, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.long && testing.Short() {
+				t.Skip("enumerates every hand")
+			}
+			h := tt.indexer
+			forms := make([][4][maxRounds]uint16, h.size())
+			seen := make([]bool, h.size())
+			enumerateRounds(h.rounds, func(cards []Card) {
+				i := h.index(cards)
+				if i < 0 || i >= h.size() {
+					t.Fatalf("index(%v) = %d, out of range", cards, i)
+				}
+				form := canonical(h.rounds, cards)
+				if seen[i] && forms[i] != form {
+					t.Fatalf("index(%v) = %d, shared with a different hand", cards, i)
+				}
+				seen[i], forms[i] = true, form
+			})
+			for i := range seen {
+				if !seen[i] {
+					t.Fatalf("no hand has index %d", i)
+				}
+				if got := canonical(h.rounds, h.unindex(int64(i))); got != forms[i] {
+					t.Fatalf("unindex(%d) is not the hand indexed there", i)
+				}
+			}
+		})
+	}
+}
+
+func TestHandIndexer_roundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	perms := suitPermutations()
+	for _, h := range []*handIndexer{preflopIndexer, flopIndexer, turnIndexer, riverIndexer} {
+		for k := 0; k < 2000; k++ {
+			i := r.Int63n(h.size())
+			cards := h.unindex(i)
+			if got := h.index(cards); got != i {
+				t.Fatalf("index(unindex(%d)) = %d", i, got)
+			}
+			p := perms[r.Intn(len(perms))]
+			for j := range cards {
+				cards[j].suit = p[cards[j].suit]
+			}
+			if got := h.index(cards); got != i {
+				t.Fatalf("index of %d with suits changed = %d", i, got)
+			}
+		}
+	}
+}
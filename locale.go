@@ -0,0 +1,385 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rankForms are the forms of a rank's name a language needs. Languages
+// without cases use the same word for both the plain and the "of" forms.
+type rankForms struct {
+	one    string
+	many   string
+	ofOne  string
+	ofMany string
+	// feminine picks the feminine numeral for "two": две дамы, два короля.
+	feminine bool
+}
+
+// pluralForm is which form of a counted word a number takes.
+type pluralForm int
+
+const (
+	formOne pluralForm = iota
+	formFew
+	formMany
+)
+
+// message is a game prompt or announcement.
+type message int
+
+const (
+	msgYourTurn message = iota
+	msgFold
+	msgCheck
+	msgCall
+	msgRaise
+	msgAllIn
+	msgSplitPot
+	// msgChips, msgWinners and msgPlayers are counted.
+	msgChips
+	msgWinners
+	msgPlayers
+)
+
+// catalog holds everything a language needs to name cards and hands and to
+// talk to players.
+type catalog struct {
+	ranks      [13]rankForms
+	suits      [4]string
+	card       func(rank rankForms, suit string) string
+	categories [9]string
+	and        string
+	// plural picks the form a number takes.
+	plural func(n int) pluralForm
+	// messages hold each message in its one, few and many forms. Messages
+	// that are not counted use the first.
+	messages map[message][3]string
+	describe func(c *catalog, r HandRank, ranks [5]Rank) string
+}
+
+// oneOrMany is the plural rule of English, German and Spanish.
+func oneOrMany(n int) pluralForm {
+	if n == 1 {
+		return formOne
+	}
+	return formMany
+}
+
+// slavic is the Russian plural rule: 1, 21, 31 take one form, 2-4, 22-24
+// another and everything else, including 11-14, a third.
+func slavic(n int) pluralForm {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return formOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return formFew
+	}
+	return formMany
+}
+
+func (c *catalog) rank(r Rank) rankForms {
+	return c.ranks[r-2]
+}
+
+// join joins names as "King, Nine and Four" in the catalog's language.
+func (c *catalog) join(ranks []Rank, form func(rankForms) string) string {
+	names := make([]string, 0, len(ranks))
+	for _, r := range ranks {
+		if r != 0 {
+			names = append(names, form(c.rank(r)))
+		}
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " " + c.and + " " + names[len(names)-1]
+}
+
+func one(f rankForms) string { return f.one }
+
+// cardName names a card, such as "Ace of Spades" or "туз пик".
+func (c *catalog) cardName(card Card) string {
+	return c.card(c.rank(card.rank), c.suits[card.suit])
+}
+
+func (c *catalog) message(m message, n int) string {
+	forms := c.messages[m]
+	text := forms[c.plural(n)]
+	if text == "" {
+		text = forms[0]
+	}
+	if strings.Contains(text, "%d") {
+		return fmt.Sprintf(text, n)
+	}
+	return text
+}
+
+var catalogs = map[Language]*catalog{
+	English: {
+		ranks: [13]rankForms{
+			{one: "Two", many: "Twos"}, {one: "Three", many: "Threes"}, {one: "Four", many: "Fours"},
+			{one: "Five", many: "Fives"}, {one: "Six", many: "Sixes"}, {one: "Seven", many: "Sevens"},
+			{one: "Eight", many: "Eights"}, {one: "Nine", many: "Nines"}, {one: "Ten", many: "Tens"},
+			{one: "Jack", many: "Jacks"}, {one: "Queen", many: "Queens"}, {one: "King", many: "Kings"},
+			{one: "Ace", many: "Aces"},
+		},
+		suits: [4]string{"Spades", "Diamonds", "Clubs", "Hearts"},
+		card:  func(r rankForms, suit string) string { return r.one + " of " + suit },
+		and:   "and",
+		categories: [9]string{"High Card", "Pair", "Two Pair", "Three of a Kind", "Straight", "Flush",
+			"Full House", "Four of a Kind", "Straight Flush"},
+		plural: oneOrMany,
+		messages: map[message][3]string{
+			msgYourTurn: {"Your turn"},
+			msgFold:     {"Fold"},
+			msgCheck:    {"Check"},
+			msgCall:     {"Call"},
+			msgRaise:    {"Raise"},
+			msgAllIn:    {"All in"},
+			msgSplitPot: {"Split pot"},
+			msgChips:    {"%d chip", "", "%d chips"},
+			msgWinners:  {"%d winner", "", "%d winners"},
+			msgPlayers:  {"%d player", "", "%d players"},
+		},
+		describe: func(_ *catalog, r HandRank, ranks [5]Rank) string { return describe(r, ranks) },
+	},
+	German: {
+		ranks: [13]rankForms{
+			{one: "Zwei", many: "Zweien"}, {one: "Drei", many: "Dreien"}, {one: "Vier", many: "Vieren"},
+			{one: "Fünf", many: "Fünfen"}, {one: "Sechs", many: "Sechsen"}, {one: "Sieben", many: "Siebenen"},
+			{one: "Acht", many: "Achten"}, {one: "Neun", many: "Neunen"}, {one: "Zehn", many: "Zehnen"},
+			{one: "Bube", many: "Buben"}, {one: "Dame", many: "Damen"}, {one: "König", many: "Könige"},
+			{one: "Ass", many: "Asse"},
+		},
+		suits: [4]string{"Pik", "Karo", "Kreuz", "Herz"},
+		card:  func(r rankForms, suit string) string { return suit + " " + r.one },
+		and:   "und",
+		categories: [9]string{"Höchste Karte", "Ein Paar", "Zwei Paare", "Drilling", "Straße", "Flush",
+			"Full House", "Vierling", "Straight Flush"},
+		plural: oneOrMany,
+		messages: map[message][3]string{
+			msgYourTurn: {"Du bist dran"},
+			msgFold:     {"Passen"},
+			msgCheck:    {"Schieben"},
+			msgCall:     {"Mitgehen"},
+			msgRaise:    {"Erhöhen"},
+			msgAllIn:    {"All-in"},
+			msgSplitPot: {"Geteilter Pot"},
+			msgChips:    {"%d Chip", "", "%d Chips"},
+			msgWinners:  {"%d Gewinner", "", "%d Gewinner"},
+			msgPlayers:  {"%d Spieler", "", "%d Spieler"},
+		},
+		describe: describeGerman,
+	},
+	Russian: {
+		ranks: [13]rankForms{
+			{one: "двойка", many: "двойки", ofOne: "двойки", ofMany: "двоек", feminine: true},
+			{one: "тройка", many: "тройки", ofOne: "тройки", ofMany: "троек", feminine: true},
+			{one: "четвёрка", many: "четвёрки", ofOne: "четвёрки", ofMany: "четвёрок", feminine: true},
+			{one: "пятёрка", many: "пятёрки", ofOne: "пятёрки", ofMany: "пятёрок", feminine: true},
+			{one: "шестёрка", many: "шестёрки", ofOne: "шестёрки", ofMany: "шестёрок", feminine: true},
+			{one: "семёрка", many: "семёрки", ofOne: "семёрки", ofMany: "семёрок", feminine: true},
+			{one: "восьмёрка", many: "восьмёрки", ofOne: "восьмёрки", ofMany: "восьмёрок", feminine: true},
+			{one: "девятка", many: "девятки", ofOne: "девятки", ofMany: "девяток", feminine: true},
+			{one: "десятка", many: "десятки", ofOne: "десятки", ofMany: "десяток", feminine: true},
+			{one: "валет", many: "валеты", ofOne: "валета", ofMany: "валетов"},
+			{one: "дама", many: "дамы", ofOne: "дамы", ofMany: "дам", feminine: true},
+			{one: "король", many: "короли", ofOne: "короля", ofMany: "королей"},
+			{one: "туз", many: "тузы", ofOne: "туза", ofMany: "тузов"},
+		},
+		// suits are named in the genitive plural, as in "туз пик"
+		suits: [4]string{"пик", "бубен", "треф", "червей"},
+		card:  func(r rankForms, suit string) string { return r.one + " " + suit },
+		and:   "и",
+		categories: [9]string{"Старшая карта", "Пара", "Две пары", "Сет", "Стрит", "Флеш",
+			"Фулл-хаус", "Каре", "Стрит-флеш"},
+		plural: slavic,
+		messages: map[message][3]string{
+			msgYourTurn: {"Ваш ход"},
+			msgFold:     {"Пас"},
+			msgCheck:    {"Чек"},
+			msgCall:     {"Колл"},
+			msgRaise:    {"Рейз"},
+			msgAllIn:    {"Олл-ин"},
+			msgSplitPot: {"Банк делится"},
+			msgChips:    {"%d фишка", "%d фишки", "%d фишек"},
+			msgWinners:  {"%d победитель", "%d победителя", "%d победителей"},
+			msgPlayers:  {"%d игрок", "%d игрока", "%d игроков"},
+		},
+		describe: describeRussian,
+	},
+	Spanish: {
+		ranks: [13]rankForms{
+			{one: "dos", many: "doses"}, {one: "tres", many: "treses"}, {one: "cuatro", many: "cuatros"},
+			{one: "cinco", many: "cincos"}, {one: "seis", many: "seises"}, {one: "siete", many: "sietes"},
+			{one: "ocho", many: "ochos"}, {one: "nueve", many: "nueves"}, {one: "diez", many: "dieces"},
+			{one: "jota", many: "jotas"}, {one: "reina", many: "reinas"}, {one: "rey", many: "reyes"},
+			{one: "as", many: "ases"},
+		},
+		suits: [4]string{"picas", "diamantes", "tréboles", "corazones"},
+		card:  func(r rankForms, suit string) string { return r.one + " de " + suit },
+		and:   "y",
+		categories: [9]string{"Carta alta", "Pareja", "Doble pareja", "Trío", "Escalera", "Color",
+			"Full", "Póker", "Escalera de color"},
+		plural: oneOrMany,
+		messages: map[message][3]string{
+			msgYourTurn: {"Tu turno"},
+			msgFold:     {"No ir"},
+			msgCheck:    {"Pasar"},
+			msgCall:     {"Igualar"},
+			msgRaise:    {"Subir"},
+			msgAllIn:    {"All-in"},
+			msgSplitPot: {"Bote dividido"},
+			msgChips:    {"%d ficha", "", "%d fichas"},
+			msgWinners:  {"%d ganador", "", "%d ganadores"},
+			msgPlayers:  {"%d jugador", "", "%d jugadores"},
+		},
+		describe: describeSpanish,
+	},
+}
+
+// localize returns the catalog for a language, falling back to English.
+func localize(lang Language) *catalog {
+	if c, ok := catalogs[lang]; ok {
+		return c
+	}
+	return catalogs[English]
+}
+
+func describeGerman(c *catalog, r HandRank, ranks [5]Rank) string {
+	name := c.categories[r]
+	rk := func(i int) rankForms { return c.rank(ranks[i]) }
+	switch r {
+	case HighCard:
+		return fmt.Sprintf("%s, %s mit %s", name, rk(0).one, c.join(ranks[1:], one))
+	case Pair:
+		return fmt.Sprintf("%s %s mit %s", name, rk(0).many, c.join(ranks[1:], one))
+	case TwoPair:
+		return fmt.Sprintf("%s, %s und %s mit %s als Kicker", name, rk(0).many, rk(1).many, rk(2).one)
+	case ThreeOfAKind:
+		return fmt.Sprintf("%s, %s mit %s", name, rk(0).many, c.join(ranks[1:], one))
+	case Straight:
+		if ranks[0] == Five {
+			return name + ", Fünf hoch (Wheel)"
+		}
+		return fmt.Sprintf("%s, %s hoch", name, rk(0).one)
+	case Flush:
+		return fmt.Sprintf("%s, %s hoch mit %s", name, rk(0).one, c.join(ranks[1:], one))
+	case FullHouse:
+		return fmt.Sprintf("%s, drei %s und zwei %s", name, rk(0).many, rk(1).many)
+	case FourOfAKind:
+		return fmt.Sprintf("%s, %s mit %s als Kicker", name, rk(0).many, rk(1).one)
+	case StraightFlush:
+		switch ranks[0] {
+		case Ace:
+			return "Royal Flush"
+		case Five:
+			return name + ", Fünf hoch (Steel Wheel)"
+		}
+		return fmt.Sprintf("%s, %s hoch", name, rk(0).one)
+	}
+	return name
+}
+
+// describeRussian uses the genitive for "a pair of" and "up to", and the
+// counting forms after three and two in a full house.
+func describeRussian(c *catalog, r HandRank, ranks [5]Rank) string {
+	name := c.categories[r]
+	rk := func(i int) rankForms { return c.rank(ranks[i]) }
+	two := func(f rankForms) string {
+		if f.feminine {
+			return "две " + f.ofOne
+		}
+		return "два " + f.ofOne
+	}
+	switch r {
+	case HighCard:
+		return fmt.Sprintf("%s %s, кикеры: %s", name, rk(0).one, c.join(ranks[1:], one))
+	case Pair:
+		return fmt.Sprintf("%s %s, кикеры: %s", name, rk(0).ofMany, c.join(ranks[1:], one))
+	case TwoPair:
+		return fmt.Sprintf("%s: %s и %s, кикер %s", name, rk(0).many, rk(1).many, rk(2).one)
+	case ThreeOfAKind:
+		return fmt.Sprintf("%s %s, кикеры: %s", name, rk(0).ofMany, c.join(ranks[1:], one))
+	case Straight:
+		if ranks[0] == Five {
+			return name + " до пятёрки (колесо)"
+		}
+		return fmt.Sprintf("%s до %s", name, rk(0).ofOne)
+	case Flush:
+		return fmt.Sprintf("%s до %s, кикеры: %s", name, rk(0).ofOne, c.join(ranks[1:], one))
+	case FullHouse:
+		return fmt.Sprintf("%s: три %s и %s", name, rk(0).ofOne, two(rk(1)))
+	case FourOfAKind:
+		return fmt.Sprintf("%s %s, кикер %s", name, rk(0).ofMany, rk(1).one)
+	case StraightFlush:
+		switch ranks[0] {
+		case Ace:
+			return "Роял-флеш"
+		case Five:
+			return name + " до пятёрки (колесо)"
+		}
+		return fmt.Sprintf("%s до %s", name, rk(0).ofOne)
+	}
+	return name
+}
+
+func describeSpanish(c *catalog, r HandRank, ranks [5]Rank) string {
+	name := c.categories[r]
+	rk := func(i int) rankForms { return c.rank(ranks[i]) }
+	switch r {
+	case HighCard:
+		return fmt.Sprintf("%s, %s con %s", name, rk(0).one, c.join(ranks[1:], one))
+	case Pair:
+		return fmt.Sprintf("%s de %s con %s", name, rk(0).many, c.join(ranks[1:], one))
+	case TwoPair:
+		return fmt.Sprintf("%s, %s y %s con %s de kicker", name, rk(0).many, rk(1).many, rk(2).one)
+	case ThreeOfAKind:
+		return fmt.Sprintf("%s de %s con %s", name, rk(0).many, c.join(ranks[1:], one))
+	case Straight:
+		if ranks[0] == Five {
+			return name + " al cinco (rueda)"
+		}
+		return fmt.Sprintf("%s al %s", name, rk(0).one)
+	case Flush:
+		return fmt.Sprintf("%s al %s con %s", name, rk(0).one, c.join(ranks[1:], one))
+	case FullHouse:
+		return fmt.Sprintf("%s de %s y %s", name, rk(0).many, rk(1).many)
+	case FourOfAKind:
+		return fmt.Sprintf("%s de %s con %s de kicker", name, rk(0).many, rk(1).one)
+	case StraightFlush:
+		switch ranks[0] {
+		case Ace:
+			return "Escalera real"
+		case Five:
+			return name + " al cinco (rueda)"
+		}
+		return fmt.Sprintf("%s al %s", name, rk(0).one)
+	}
+	return name
+}
+
+// describeIn says what a hand is in the given language.
+func describeIn(lang Language, r HandRank, ranks [5]Rank) string {
+	c := localize(lang)
+	return c.describe(c, r, ranks)
+}
+
+func (h *Hand) describeIn(lang Language) string {
+	return describeIn(lang, h.handRank, h.ranks)
+}
+
+func (v handValue) describeIn(lang Language) string {
+	return describeIn(lang, v.handRank(), v.ranks())
+}
+
+// describe says what a hand is in the table's language.
+func (t *Table) describe(v handValue) string {
+	return v.describeIn(t.lang)
+}
+
+// say returns a message in the table's language.
+func (t *Table) say(m message, n int) string {
+	return localize(t.lang).message(m, n)
+}
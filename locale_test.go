@@ -0,0 +1,109 @@
+package poker
+
+import "testing"
+
+func Test_cardName(t *testing.T) {
+	tests := []struct {
+		lang Language
+		card Card
+		want string
+	}{
+		{English, Card{Ace, Spade}, "Ace of Spades"},
+		{German, Card{Ace, Spade}, "Pik Ass"},
+		{German, Card{Queen, Heart}, "Herz Dame"},
+		{Russian, Card{Ace, Spade}, "туз пик"},
+		{Russian, Card{Ten, Diamond}, "десятка бубен"},
+		{Spanish, Card{Ace, Spade}, "as de picas"},
+		{Spanish, Card{Jack, Club}, "jota de tréboles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := localize(tt.lang).cardName(tt.card); got != tt.want {
+				t.Errorf("cardName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_describeIn(t *testing.T) {
+	pair := [5]Card{{Six, Spade}, {Six, Heart}, {King, Club}, {Four, Club}, {Two, Diamond}}
+	full := [5]Card{{Ace, Spade}, {Ten, Heart}, {Ace, Club}, {Ten, Club}, {Ace, Diamond}}
+	fullQueens := [5]Card{{King, Spade}, {Queen, Heart}, {King, Club}, {Queen, Club}, {King, Diamond}}
+	straight := [5]Card{{Nine, Spade}, {Eight, Heart}, {Seven, Club}, {Six, Club}, {Five, Diamond}}
+	wheel := [5]Card{{Ace, Spade}, {Two, Heart}, {Three, Club}, {Four, Club}, {Five, Diamond}}
+	quads := [5]Card{{Queen, Spade}, {Queen, Heart}, {Queen, Club}, {Queen, Diamond}, {Eight, Diamond}}
+	royal := [5]Card{{Ace, Club}, {King, Club}, {Queen, Club}, {Jack, Club}, {Ten, Club}}
+
+	tests := []struct {
+		lang  Language
+		cards [5]Card
+		want  string
+	}{
+		{English, pair, "Pair of Sixes with King, Four and Two"},
+		{German, pair, "Ein Paar Sechsen mit König, Vier und Zwei"},
+		{German, full, "Full House, drei Asse und zwei Zehnen"},
+		{German, straight, "Straße, Neun hoch"},
+		{Russian, pair, "Пара шестёрок, кикеры: король, четвёрка и двойка"},
+		{Russian, full, "Фулл-хаус: три туза и две десятки"},
+		{Russian, fullQueens, "Фулл-хаус: три короля и две дамы"},
+		{Russian, straight, "Стрит до девятки"},
+		{Russian, wheel, "Стрит до пятёрки (колесо)"},
+		{Russian, quads, "Каре дам, кикер восьмёрка"},
+		{Russian, royal, "Роял-флеш"},
+		{Spanish, pair, "Pareja de seises con rey, cuatro y dos"},
+		{Spanish, full, "Full de ases y dieces"},
+		{Spanish, straight, "Escalera al nueve"},
+		{Spanish, royal, "Escalera real"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			h := Hand{cards: tt.cards}
+			h.score()
+			if got := h.describeIn(tt.lang); got != tt.want {
+				t.Errorf("describeIn() = %q, want %q", got, tt.want)
+			}
+			if got := evaluate(tt.cards[:]).describeIn(tt.lang); got != tt.want {
+				t.Errorf("handValue describeIn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_message(t *testing.T) {
+	tests := []struct {
+		lang Language
+		msg  message
+		n    int
+		want string
+	}{
+		{English, msgChips, 1, "1 chip"},
+		{English, msgChips, 2, "2 chips"},
+		{German, msgYourTurn, 0, "Du bist dran"},
+		{Spanish, msgPlayers, 3, "3 jugadores"},
+		{Russian, msgChips, 1, "1 фишка"},
+		{Russian, msgChips, 2, "2 фишки"},
+		{Russian, msgChips, 5, "5 фишек"},
+		{Russian, msgChips, 11, "11 фишек"},
+		{Russian, msgChips, 12, "12 фишек"},
+		{Russian, msgChips, 21, "21 фишка"},
+		{Russian, msgChips, 22, "22 фишки"},
+		{Russian, msgWinners, 2, "2 победителя"},
+		{Language(9), msgFold, 0, "Fold"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := localize(tt.lang).message(tt.msg, tt.n); got != tt.want {
+				t.Errorf("message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	table := newTable(6, 1, 2, 0, DeadButton)
+	table.lang = Russian
+	if got := table.say(msgPlayers, 4); got != "4 игрока" {
+		t.Errorf("say() = %q, want %q", got, "4 игрока")
+	}
+	if got := table.describe(evaluate([]Card{{Ace, Club}, {King, Club}, {Queen, Club}, {Jack, Club}, {Ten, Club}})); got != "Роял-флеш" {
+		t.Errorf("describe() = %q, want %q", got, "Роял-флеш")
+	}
+}
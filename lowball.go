@@ -0,0 +1,95 @@
+package poker
+
+import "fmt"
+
+// scoreDeuceToSeven scores the hand for deuce-to-seven lowball. Aces are
+// always high, so A-2-3-4-5 is no straight but an ace-high hand.
+func (h *Hand) scoreDeuceToSeven() {
+	h.score()
+	if (h.handRank == Straight || h.handRank == StraightFlush) && h.ranks[0] == Five {
+		h.ranks = [5]Rank{Ace, Five, Four, Three, Two}
+		if h.handRank == Straight {
+			h.handRank = HighCard
+		} else {
+			h.handRank = Flush
+		}
+	}
+}
+
+// lowballPlay returns the lowest hands, so 7-5-4-3-2 offsuit is the nuts.
+func lowballPlay(hands []Hand) []Hand {
+	winners := make([]Hand, 0)
+
+	for _, h := range hands {
+		h.scoreDeuceToSeven()
+		if len(winners) == 0 {
+			winners = append(winners, h)
+			continue
+		}
+		switch c := compare(&h, &winners[0]); {
+		case c < 0:
+			winners = winners[:0]
+			winners = append(winners, h)
+		case c == 0:
+			winners = append(winners, h)
+		}
+	}
+
+	return winners
+}
+
+// deuceToSevenDiscard keeps one card of each rank up to an eight and throws
+// everything else.
+func deuceToSevenDiscard(cards []Card) []int {
+	seen := make(map[Rank]bool)
+	idx := make([]int, 0)
+	for i, c := range cards {
+		if c.rank > Eight || seen[c.rank] {
+			idx = append(idx, i)
+			continue
+		}
+		seen[c.rank] = true
+	}
+
+	return idx
+}
+
+// tripleDraw deals five cards to every player and runs the three draws of
+// 2-7 Triple Draw.
+func tripleDraw(numHands int, discard drawStrategy) ([]Hand, error) {
+	cards, s, err := dealDraw(numHands, 5)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawRounds(cards, s, 3, discard); err != nil {
+		return nil, err
+	}
+
+	hands := make([]Hand, numHands)
+	for i := range hands {
+		hands[i].cards = [5]Card(cards[i])
+	}
+
+	return hands, nil
+}
+
+func deuceToSeven(numHands int) error {
+	hands, err := tripleDraw(numHands, deuceToSevenDiscard)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	for _, h := range hands {
+		fmt.Printf("%s\n", h.String())
+	}
+
+	winners := lowballPlay(hands)
+	fmt.Printf("%d Winner(s):\n", len(winners))
+
+	for _, w := range winners {
+		fmt.Printf("%s: %s, %s\n", w.String(), w.handRank, w.ranks)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestHand_scoreDeuceToSeven(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     [5]Card
+		wantScore HandRank
+		wantRanks [5]Rank
+	}{
+		{
+			name: "wheel is ace high",
+			cards: [5]Card{
+				{rank: Ace, suit: Spade},
+				{rank: Two, suit: Diamond},
+				{rank: Three, suit: Heart},
+				{rank: Four, suit: Club},
+				{rank: Five, suit: Spade},
+			},
+			wantScore: HighCard,
+			wantRanks: [5]Rank{Ace, Five, Four, Three, Two},
+		},
+		{
+			name: "suited wheel is a flush",
+			cards: [5]Card{
+				{rank: Ace, suit: Club},
+				{rank: Two, suit: Club},
+				{rank: Three, suit: Club},
+				{rank: Four, suit: Club},
+				{rank: Five, suit: Club},
+			},
+			wantScore: Flush,
+			wantRanks: [5]Rank{Ace, Five, Four, Three, Two},
+		},
+		{
+			name: "straight counts",
+			cards: [5]Card{
+				{rank: Six, suit: Spade},
+				{rank: Two, suit: Diamond},
+				{rank: Three, suit: Heart},
+				{rank: Four, suit: Club},
+				{rank: Five, suit: Spade},
+			},
+			wantScore: Straight,
+			wantRanks: [5]Rank{Six, Five, Four, Three, Two},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hand{
+				cards: tt.cards,
+			}
+			h.scoreDeuceToSeven()
+			if got := h.handRank; got != tt.wantScore {
+				t.Errorf("scoreDeuceToSeven() = %v, want %v", got, tt.wantScore)
+			}
+			if got := h.ranks; got != tt.wantRanks {
+				t.Errorf("scoreDeuceToSeven() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+}
+
+func Test_lowballPlay(t *testing.T) {
+	number := Hand{cards: [5]Card{
+		{rank: Seven, suit: Spade},
+		{rank: Five, suit: Diamond},
+		{rank: Four, suit: Heart},
+		{rank: Three, suit: Club},
+		{rank: Two, suit: Spade},
+	}}
+	eight := Hand{cards: [5]Card{
+		{rank: Eight, suit: Spade},
+		{rank: Five, suit: Heart},
+		{rank: Four, suit: Diamond},
+		{rank: Three, suit: Spade},
+		{rank: Two, suit: Club},
+	}}
+	wheel := Hand{cards: [5]Card{
+		{rank: Ace, suit: Heart},
+		{rank: Five, suit: Club},
+		{rank: Four, suit: Club},
+		{rank: Three, suit: Heart},
+		{rank: Two, suit: Heart},
+	}}
+	winners := lowballPlay([]Hand{wheel, eight, number})
+	if len(winners) != 1 || winners[0].ranks[0] != Seven {
+		t.Errorf("lowballPlay() = %v, want seven low", winners)
+	}
+}
+
+func Test_tripleDraw(t *testing.T) {
+	tests := []struct {
+		name     string
+		numHands int
+		wantErr  bool
+	}{
+		{
+			name:     "triple draw 2",
+			numHands: 2,
+		},
+		{
+			name:     "triple draw 6",
+			numHands: 6,
+		},
+		{
+			name:     "triple draw 11",
+			numHands: 11,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hands, err := tripleDraw(tt.numHands, deuceToSevenDiscard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tripleDraw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			seen := make(map[Card]bool)
+			for _, h := range hands {
+				for _, c := range h.cards {
+					if seen[c] {
+						t.Errorf("tripleDraw() dealt %v twice", c)
+					}
+					seen[c] = true
+				}
+			}
+		})
+	}
+}
@@ -45,3 +45,102 @@ const (
 	FourOfAKind
 	StraightFlush
 )
+
+type BadugiRank int
+
+const (
+	OneCardBadugi BadugiRank = iota + 1
+	TwoCardBadugi
+	ThreeCardBadugi
+	Badugi
+)
+
+type ThreeCardRank int
+
+const (
+	ThreeCardHigh ThreeCardRank = iota
+	ThreeCardPair
+	ThreeCardFlush
+	ThreeCardStraight
+	ThreeCardTrips
+	ThreeCardStraightFlush
+)
+
+type DrawKind int
+
+const (
+	FlushDraw DrawKind = iota
+	OpenEnded
+	Gutshot
+	BackdoorFlush
+	BackdoorStraight
+	Overcards
+)
+
+type SuitTexture int
+
+const (
+	Rainbow SuitTexture = iota
+	TwoTone
+	ThreeFlush
+	FourFlush
+	Monotone
+)
+
+type BoardPairing int
+
+const (
+	Unpaired BoardPairing = iota
+	Paired
+	TwoPaired
+	TripsBoard
+	FullHouseBoard
+	QuadsBoard
+)
+
+type HighCardClass int
+
+const (
+	LowBoard HighCardClass = iota
+	MiddleBoard
+	BroadwayBoard
+	AceHigh
+)
+
+type Language int
+
+const (
+	English Language = iota
+	German
+	Russian
+	Spanish
+)
+
+type Street int
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+	ThirdStreet
+	FourthStreet
+	FifthStreet
+	SixthStreet
+	SeventhStreet
+)
+
+type ActionKind int
+
+const (
+	PostAnte ActionKind = iota
+	PostSmallBlind
+	PostBigBlind
+	PostDead
+	PostBringIn
+	Fold
+	Check
+	Call
+	Bet
+	Raise
+)
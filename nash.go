@@ -0,0 +1,156 @@
+package poker
+
+import "fmt"
+
+// pushFoldNash is an equilibrium of the push/fold game: every player moves
+// all in or folds when the action is folded to them, and calls or folds when
+// someone has moved in. Players act in order with the last two posting the
+// small and big blinds, so heads up the small blind acts first. Once a
+// player calls nobody else comes in, which keeps every all-in heads up.
+type pushFoldNash struct {
+	// stacks are in big blinds before the blinds go in.
+	stacks []float64
+	// push holds how often each player moves in with each class when folded
+	// to and call[i][j] how often player j calls player i.
+	push [][169]float64
+	call [][][169]float64
+}
+
+func (n *pushFoldNash) posted(i int) float64 {
+	switch len(n.stacks) - i {
+	case 1:
+		return 1
+	case 2:
+		return 0.5
+	}
+	return 0
+}
+
+// allIn returns what player i ends up with after an all-in against player j
+// with the given equity.
+func (n *pushFoldNash) allIn(i, j int, equity float64) float64 {
+	matched := n.stacks[i]
+	if n.stacks[j] < matched {
+		matched = n.stacks[j]
+	}
+	dead := 1.5 - n.posted(i) - n.posted(j)
+	return n.stacks[i] - matched + equity*(2*matched+dead)
+}
+
+// respond sets every decision to its best response against the current
+// strategies, blended in with the given weight.
+func (n *pushFoldNash) respond(m *equityMatrix, weight float64) {
+	players := len(n.stacks)
+	push := make([][169]float64, players)
+	call := make([][][169]float64, players)
+	for i := 0; i < players-1; i++ {
+		call[i] = make([][169]float64, players)
+		for h := 0; h < 169; h++ {
+			fold := n.stacks[i] - n.posted(i)
+			reach := 1.0
+			ev := 0.0
+			for j := i + 1; j < players; j++ {
+				equity, combos := m.versus(h, &n.call[i][j])
+				calls := combos / 1225
+				ev += reach * calls * n.allIn(i, j, equity)
+				reach *= 1 - calls
+			}
+			ev += reach * (n.stacks[i] + 1.5 - n.posted(i))
+			if ev > fold {
+				push[i][h] = 1
+			}
+
+			for j := i + 1; j < players; j++ {
+				equity, _ := m.versus(h, &n.push[i])
+				if n.allIn(j, i, equity) > n.stacks[j]-n.posted(j) {
+					call[i][j][h] = 1
+				}
+			}
+		}
+	}
+
+	for i := range push {
+		for h := range push[i] {
+			n.push[i][h] += weight * (push[i][h] - n.push[i][h])
+			for j := range call[i] {
+				n.call[i][j][h] += weight * (call[i][j][h] - n.call[i][j][h])
+			}
+		}
+	}
+}
+
+// solvePushFold finds the equilibrium for the given stacks in big blinds by
+// fictitious play: each round every player best responds to the average of
+// the strategies so far.
+func solvePushFold(stacks []float64, m *equityMatrix, iterations int) *pushFoldNash {
+	n := &pushFoldNash{
+		stacks: stacks,
+		push:   make([][169]float64, len(stacks)),
+		call:   make([][][169]float64, len(stacks)),
+	}
+	for i := range n.call {
+		n.call[i] = make([][169]float64, len(stacks))
+	}
+	for k := 0; k < iterations; k++ {
+		n.respond(m, 1/float64(k+1))
+	}
+
+	return n
+}
+
+// nashCharts solves the game for every stack depth with all players equally
+// deep.
+func nashCharts(players int, depths []float64, m *equityMatrix, iterations int) []*pushFoldNash {
+	solved := make([]*pushFoldNash, len(depths))
+	for d, depth := range depths {
+		stacks := make([]float64, players)
+		for i := range stacks {
+			stacks[i] = depth
+		}
+		solved[d] = solvePushFold(stacks, m, iterations)
+	}
+
+	return solved
+}
+
+func (n *pushFoldNash) pushChart(i int) chart {
+	return chartOf(&n.push[i])
+}
+
+func (n *pushFoldNash) callChart(pusher, caller int) chart {
+	return chartOf(&n.call[pusher][caller])
+}
+
+// position names seat i of n by where it acts before the flop.
+func position(i, n int) string {
+	switch n - i {
+	case 1:
+		return "BB"
+	case 2:
+		return "SB"
+	case 3:
+		return "BTN"
+	case 4:
+		return "CO"
+	case 5:
+		return "HJ"
+	}
+	if i == 0 {
+		return "UTG"
+	}
+	return fmt.Sprintf("UTG+%d", i)
+}
+
+// String prints every push chart and call chart with a title.
+func (n *pushFoldNash) String() string {
+	players := len(n.stacks)
+	buf := ""
+	for i := 0; i < players-1; i++ {
+		buf += fmt.Sprintf("%s push, %g BB\n%s\n", position(i, players), n.stacks[i], n.pushChart(i))
+		for j := i + 1; j < players; j++ {
+			buf += fmt.Sprintf("%s call vs %s, %g BB\n%s\n", position(j, players), position(i, players), n.stacks[j], n.callChart(i, j))
+		}
+	}
+
+	return buf
+}
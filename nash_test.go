@@ -0,0 +1,66 @@
+package poker
+
+import (
+	"strings"
+	"testing"
+)
+
+// share returns the part of all 1,326 combos a strategy plays.
+func share(freq *[169]float64) float64 {
+	combos := 0.0
+	for i, h := range startingHands() {
+		combos += freq[i] * float64(h.combos())
+	}
+	return combos / 1326
+}
+
+func Test_solvePushFold(t *testing.T) {
+	m := preflopEquity()
+	solved := nashCharts(2, []float64{5, 10, 20}, m, 200)
+
+	aces := StartingHand{high: Ace, low: Ace}.index()
+	for _, n := range solved {
+		if n.push[0][aces] < 0.99 || n.call[0][1][aces] < 0.99 {
+			t.Errorf("%g BB: AA pushes %.2f and calls %.2f, want always", n.stacks[0], n.push[0][aces], n.call[0][1][aces])
+		}
+	}
+	// heads up at ten big blinds the small blind moves in with close to 58%
+	// of hands and the big blind calls with close to 37%
+	if push := share(&solved[1].push[0]); push < 0.5 || push > 0.66 {
+		t.Errorf("10 BB push share = %.3f, want about 0.58", push)
+	}
+	if call := share(&solved[1].call[0][1]); call < 0.3 || call > 0.45 {
+		t.Errorf("10 BB call share = %.3f, want about 0.37", call)
+	}
+	for d := 1; d < len(solved); d++ {
+		if share(&solved[d].push[0]) >= share(&solved[d-1].push[0]) {
+			t.Errorf("pushes %g BB deep as often as %g BB deep", solved[d].stacks[0], solved[d-1].stacks[0])
+		}
+	}
+
+	three := solvePushFold([]float64{10, 10, 10}, m, 200)
+	if share(&three.push[0]) >= share(&three.push[1]) {
+		t.Errorf("button pushes %.3f, want tighter than the small blind's %.3f", share(&three.push[0]), share(&three.push[1]))
+	}
+	text := three.String()
+	for _, title := range []string{"BTN push, 10 BB", "SB call vs BTN", "BB call vs SB"} {
+		if !strings.Contains(text, title) {
+			t.Errorf("String() has no %q chart", title)
+		}
+	}
+}
+
+func Test_chart(t *testing.T) {
+	var freq [169]float64
+	for _, h := range []StartingHand{{high: Ace, low: Ace}, {high: Ace, low: King, suited: true}, {high: Ace, low: King}} {
+		freq[h.index()] = 1
+	}
+	c := chartOf(&freq)
+	if c[0][0] != 1 || c[0][1] != 1 || c[1][0] != 1 || c[1][1] != 0 {
+		t.Errorf("chartOf() = %v, want AA, AKs and AKo", c[:2])
+	}
+	lines := strings.Split(c.String(), "\n")
+	if len(lines) != 14 || !strings.HasPrefix(lines[0], " AA AKs   -") || !strings.HasPrefix(lines[1], "AKo   -") {
+		t.Errorf("String() = %q", c.String())
+	}
+}
@@ -0,0 +1,111 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// holding is one strength two hole cards can have on a board, with every
+// pair of hole cards that makes it.
+type holding struct {
+	value  handValue
+	combos [][2]Card
+}
+
+// boardStrengths lists every strength a player can hold on the board, the
+// nuts first.
+func boardStrengths(board []Card) []holding {
+	left := unseen(nil, board, nil)
+	byValue := make(map[handValue]*holding)
+	cards := append(append([]Card{}, board...), Card{}, Card{})
+	for i := range left {
+		for j := i + 1; j < len(left); j++ {
+			cards[len(board)], cards[len(board)+1] = left[i], left[j]
+			v := evaluate(cards)
+			h, ok := byValue[v]
+			if !ok {
+				h = &holding{value: v}
+				byValue[v] = h
+			}
+			h.combos = append(h.combos, [2]Card{left[i], left[j]})
+		}
+	}
+
+	strengths := make([]holding, 0, len(byValue))
+	for _, h := range byValue {
+		strengths = append(strengths, *h)
+	}
+	sort.Slice(strengths, func(i, j int) bool { return strengths[i].value > strengths[j].value })
+
+	return strengths
+}
+
+// nuts returns the best hand possible on the board.
+func nuts(board []Card) holding {
+	return boardStrengths(board)[0]
+}
+
+// RelativeStrength places a hand among everything possible on its board.
+type RelativeStrength struct {
+	// place is 1 for the nuts, 2 for the second nuts and so on.
+	place int
+	// beats and ties are the shares of the hands an opponent can hold, with
+	// the player's cards out of the deck, that the hand beats and splits
+	// with.
+	beats float64
+	ties  float64
+}
+
+// relativeStrength places a player's hole cards on the board.
+func relativeStrength(hole [2]Card, board []Card) RelativeStrength {
+	cards := append(append([]Card{}, board...), hole[0], hole[1])
+	mine := evaluate(cards)
+
+	var rs RelativeStrength
+	combos, beats, ties := 0, 0, 0
+	for _, h := range boardStrengths(board) {
+		if h.value > mine {
+			rs.place++
+		}
+		for _, c := range h.combos {
+			if c[0] == hole[0] || c[0] == hole[1] || c[1] == hole[0] || c[1] == hole[1] {
+				continue
+			}
+			combos++
+			switch {
+			case h.value < mine:
+				beats++
+			case h.value == mine:
+				ties++
+			}
+		}
+	}
+	rs.place++
+	rs.beats = float64(beats) / float64(combos)
+	rs.ties = float64(ties) / float64(combos)
+
+	return rs
+}
+
+// ordinal writes 1 as 1st, 2 as 2nd and so on.
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func (rs RelativeStrength) String() string {
+	place := "the nuts"
+	if rs.place > 1 {
+		place = ordinal(rs.place) + " nuts"
+	}
+	return fmt.Sprintf("%s, beats %.0f%% of possible hands", place, 100*rs.beats)
+}
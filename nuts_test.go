@@ -0,0 +1,77 @@
+package poker
+
+import "testing"
+
+func Test_nuts(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  []Card
+		want   HandRank
+		high   Rank
+		combos int
+	}{
+		{"royal possible", []Card{{Ace, Heart}, {King, Heart}, {Seven, Club}, {Queen, Heart}, {Two, Spade}}, StraightFlush, Ace, 1},
+		{"quads on a paired board", []Card{{Nine, Spade}, {Nine, Heart}, {Five, Club}, {Two, Diamond}, {Jack, Club}}, FourOfAKind, Nine, 1},
+		{"set on a dry flop", []Card{{King, Spade}, {Eight, Heart}, {Three, Club}}, ThreeOfAKind, King, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := nuts(tt.board)
+			if n.value.handRank() != tt.want || n.value.ranks()[0] != tt.high || len(n.combos) != tt.combos {
+				t.Errorf("nuts() = %s %v in %d combos, want %s %s in %d", n.value.handRank(), n.value.ranks(), len(n.combos), tt.want, tt.high, tt.combos)
+			}
+		})
+	}
+
+	total := 0
+	board := []Card{{Ten, Spade}, {Seven, Heart}, {Two, Diamond}, {Jack, Club}}
+	strengths := boardStrengths(board)
+	for i, h := range strengths {
+		if i > 0 && h.value >= strengths[i-1].value {
+			t.Fatalf("strengths out of order at %d", i)
+		}
+		total += len(h.combos)
+	}
+	if total != 1128 {
+		t.Errorf("boardStrengths() covers %d combos, want 1128", total)
+	}
+}
+
+func Test_relativeStrength(t *testing.T) {
+	board := []Card{{Ace, Spade}, {King, Heart}, {Seven, Club}, {Seven, Diamond}, {Two, Spade}}
+	tests := []struct {
+		name  string
+		hole  [2]Card
+		place int
+		beats float64
+		text  string
+	}{
+		{"quads", [2]Card{{Seven, Spade}, {Seven, Heart}}, 1, 1, "the nuts, beats 100% of possible hands"},
+		{"aces full", [2]Card{{Ace, Heart}, {Ace, Club}}, 2, 0.998, "2nd nuts, beats 100% of possible hands"},
+		{"trips", [2]Card{{Seven, Spade}, {Three, Heart}}, 0, 0.9, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := relativeStrength(tt.hole, board)
+			if tt.place > 0 && rs.place != tt.place {
+				t.Errorf("place = %d, want %d", rs.place, tt.place)
+			}
+			if rs.beats < tt.beats || rs.beats > 1 || rs.beats+rs.ties > 1+1e-9 {
+				t.Errorf("beats = %.4f ties = %.4f, want at least %.3f", rs.beats, rs.ties, tt.beats)
+			}
+			if tt.text != "" && rs.String() != tt.text {
+				t.Errorf("String() = %q, want %q", rs.String(), tt.text)
+			}
+		})
+	}
+
+	// the board's sevens with a queen kicker split with every other queen
+	// that makes no better pair
+	rs := relativeStrength([2]Card{{Queen, Heart}, {Three, Club}}, board)
+	if rs.place < 10 || rs.ties == 0 {
+		t.Errorf("place = %d ties = %.3f, want far from the nuts with splits", rs.place, rs.ties)
+	}
+	if got := ordinal(11) + ordinal(22) + ordinal(103); got != "11th22nd103rd" {
+		t.Errorf("ordinal() = %s", got)
+	}
+}
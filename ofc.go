@@ -0,0 +1,320 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+type OFCVariant int
+
+const (
+	RegularOFC OFCVariant = iota
+	PineappleOFC
+	ProgressivePineappleOFC
+)
+
+type OFCRow int
+
+const (
+	Front OFCRow = iota
+	Middle
+	Back
+)
+
+var ofcRowSize = [...]int{Front: 3, Middle: 5, Back: 5}
+
+// OFCHand is an Open-Face Chinese Poker hand: a three-card front row and
+// five-card middle and back rows that must get stronger from front to back.
+type OFCHand struct {
+	rows [3][]Card
+}
+
+func (h *OFCHand) place(c Card, row OFCRow) error {
+	if len(h.rows[row]) == ofcRowSize[row] {
+		return fmt.Errorf("%s row is full", row)
+	}
+	h.rows[row] = append(h.rows[row], c)
+
+	return nil
+}
+
+func (h *OFCHand) complete() bool {
+	for r, cards := range h.rows {
+		if len(cards) != ofcRowSize[r] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// scoreFront scores a three-card front row on the five-card scale. Only high
+// card, pair and three of a kind count, and the missing cards rank as
+// nothing, so Q-Q-A loses to Q-Q-A-5-2.
+func scoreFront(cards [3]Card) Hand {
+	h := Hand{count: make(map[Rank]int)}
+	for _, c := range cards {
+		h.count[c.rank]++
+	}
+	rs := make([]Rank, 0, 3)
+	for r, n := range h.count {
+		switch n {
+		case 3:
+			h.handRank = ThreeOfAKind
+			h.ranks[0] = r
+		case 2:
+			h.handRank = Pair
+			h.ranks[0] = r
+		default:
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i] > rs[j] })
+	off := 0
+	if h.handRank != HighCard {
+		off = 1
+	}
+	for i, r := range rs {
+		h.ranks[i+off] = r
+	}
+	copy(h.cards[:], cards[:])
+
+	return h
+}
+
+// scoreRows returns the evaluated front, middle and back rows of a complete
+// hand.
+func (h *OFCHand) scoreRows() [3]Hand {
+	var rows [3]Hand
+	rows[Front] = scoreFront([3]Card(h.rows[Front]))
+	for _, r := range []OFCRow{Middle, Back} {
+		rows[r].cards = [5]Card(h.rows[r])
+		rows[r].score()
+	}
+
+	return rows
+}
+
+// fouled reports whether a complete hand has a row that beats the row behind
+// it.
+func (h *OFCHand) fouled() bool {
+	rows := h.scoreRows()
+	return compare(&rows[Front], &rows[Middle]) > 0 || compare(&rows[Middle], &rows[Back]) > 0
+}
+
+var backRoyalties = map[HandRank]int{
+	Straight:      2,
+	Flush:         4,
+	FullHouse:     6,
+	FourOfAKind:   10,
+	StraightFlush: 15,
+}
+
+var middleRoyalties = map[HandRank]int{
+	ThreeOfAKind:  2,
+	Straight:      4,
+	Flush:         8,
+	FullHouse:     12,
+	FourOfAKind:   20,
+	StraightFlush: 30,
+}
+
+func royal(h *Hand) bool {
+	return h.handRank == StraightFlush && h.ranks[0] == Ace
+}
+
+// frontRoyalty pays 1 for sixes up to 9 for aces, and 10 for deuces trips up
+// to 22 for aces trips.
+func frontRoyalty(h *Hand) int {
+	switch {
+	case h.handRank == ThreeOfAKind:
+		return int(h.ranks[0]) + 8
+	case h.handRank == Pair && h.ranks[0] >= Six:
+		return int(h.ranks[0]) - 5
+	}
+
+	return 0
+}
+
+// royalties returns the bonus a complete hand earns for its rows. Fouled
+// hands earn nothing.
+func (h *OFCHand) royalties() int {
+	if h.fouled() {
+		return 0
+	}
+	rows := h.scoreRows()
+	n := frontRoyalty(&rows[Front]) + middleRoyalties[rows[Middle].handRank] + backRoyalties[rows[Back].handRank]
+	if royal(&rows[Middle]) {
+		n += 20
+	}
+	if royal(&rows[Back]) {
+		n += 10
+	}
+
+	return n
+}
+
+const ofcScoop = 3
+
+// ofcScore returns the points a wins from b: one per row won, a bonus for
+// winning all three and the difference in royalties. A fouled hand loses every
+// row to a live one.
+func ofcScore(a, b *OFCHand) int {
+	fa, fb := a.fouled(), b.fouled()
+	switch {
+	case fa && fb:
+		return 0
+	case fa:
+		return -(3 + ofcScoop + b.royalties())
+	case fb:
+		return 3 + ofcScoop + a.royalties()
+	}
+
+	ra, rb := a.scoreRows(), b.scoreRows()
+	rows := 0
+	for i := range ra {
+		switch c := compare(&ra[i], &rb[i]); {
+		case c > 0:
+			rows++
+		case c < 0:
+			rows--
+		}
+	}
+	if rows == 3 {
+		rows += ofcScoop
+	}
+	if rows == -3 {
+		rows -= ofcScoop
+	}
+
+	return rows + a.royalties() - b.royalties()
+}
+
+// ofcSettle scores every pair of players and returns each player's total.
+func ofcSettle(hands []*OFCHand) []int {
+	totals := make([]int, len(hands))
+	for i := range hands {
+		for j := i + 1; j < len(hands); j++ {
+			p := ofcScore(hands[i], hands[j])
+			totals[i] += p
+			totals[j] -= p
+		}
+	}
+
+	return totals
+}
+
+// fantasyland returns how many cards a complete hand is dealt at once next
+// hand, or zero if it does not qualify. Queens or better up front qualify.
+func (h *OFCHand) fantasyland(variant OFCVariant) int {
+	if h.fouled() {
+		return 0
+	}
+	front := h.scoreRows()[Front]
+	if front.handRank == Pair && front.ranks[0] < Queen {
+		return 0
+	}
+	if front.handRank == HighCard {
+		return 0
+	}
+	switch variant {
+	case PineappleOFC:
+		return 14
+	case ProgressivePineappleOFC:
+		if front.handRank == ThreeOfAKind {
+			return 17
+		}
+		return 14 + int(front.ranks[0]-Queen)
+	}
+
+	return 13
+}
+
+// staysInFantasyland reports whether a player in Fantasyland qualifies again:
+// trips up front, a full house or better in the middle or quads or better in
+// the back.
+func (h *OFCHand) staysInFantasyland() bool {
+	if h.fouled() {
+		return false
+	}
+	rows := h.scoreRows()
+	return rows[Front].handRank == ThreeOfAKind || rows[Middle].handRank >= FullHouse ||
+		rows[Back].handRank >= FourOfAKind
+}
+
+// ofcStreet is one deal of a hand: how many cards a player gets and how many
+// of them must be set.
+type ofcStreet struct {
+	deal int
+	keep int
+}
+
+func ofcStreets(variant OFCVariant) []ofcStreet {
+	if variant == RegularOFC {
+		streets := []ofcStreet{{deal: 5, keep: 5}}
+		for i := 0; i < 8; i++ {
+			streets = append(streets, ofcStreet{deal: 1, keep: 1})
+		}
+		return streets
+	}
+
+	return []ofcStreet{{5, 5}, {3, 2}, {3, 2}, {3, 2}, {3, 2}}
+}
+
+// ofcSetter sets keep of the dealt cards into the hand and returns the rest.
+type ofcSetter func(h *OFCHand, cards []Card, keep int) []Card
+
+// ofcFill sets the highest cards first, filling the back, then the middle and
+// then the front.
+func ofcFill(h *OFCHand, cards []Card, keep int) []Card {
+	sort.Slice(cards, func(i, j int) bool { return cards[i].rank > cards[j].rank })
+	for _, c := range cards[:keep] {
+		for _, r := range []OFCRow{Back, Middle, Front} {
+			if h.place(c, r) == nil {
+				break
+			}
+		}
+	}
+
+	return cards[keep:]
+}
+
+// ofcDeal plays out one hand. Players with a non-zero fantasy count get that
+// many cards at once and set thirteen of them.
+func ofcDeal(variant OFCVariant, fantasy []int, set ofcSetter) ([]*OFCHand, error) {
+	streets := ofcStreets(variant)
+	need := 0
+	for _, n := range fantasy {
+		if n > 0 {
+			need += n
+			continue
+		}
+		for _, st := range streets {
+			need += st.deal
+		}
+	}
+	s := newStub()
+	if need > len(s.cards) {
+		return nil, fmt.Errorf("not enough cards in the deck")
+	}
+
+	hands := make([]*OFCHand, len(fantasy))
+	for i, n := range fantasy {
+		hands[i] = &OFCHand{}
+		if n > 0 {
+			cards, _ := s.draw(n)
+			set(hands[i], cards, 13)
+		}
+	}
+	for _, st := range streets {
+		for i, n := range fantasy {
+			if n > 0 {
+				continue
+			}
+			cards, _ := s.draw(st.deal)
+			set(hands[i], cards, st.keep)
+		}
+	}
+
+	return hands, nil
+}
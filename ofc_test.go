@@ -0,0 +1,191 @@
+package poker
+
+import (
+	"testing"
+)
+
+// ofcHand builds a complete hand from its front, middle and back rows.
+func ofcHand(front, middle, back []Card) *OFCHand {
+	return &OFCHand{rows: [3][]Card{front, middle, back}}
+}
+
+var (
+	ofcQueensUp = ofcHand(
+		[]Card{{Queen, Spade}, {Queen, Heart}, {Two, Club}},
+		[]Card{{Ten, Spade}, {Ten, Heart}, {Ten, Club}, {Four, Diamond}, {Three, Diamond}},
+		[]Card{{Nine, Heart}, {Eight, Heart}, {Six, Heart}, {Five, Heart}, {Two, Heart}},
+	)
+	ofcFouled = ofcHand(
+		[]Card{{Ace, Spade}, {Ace, Club}, {King, Club}},
+		[]Card{{Jack, Spade}, {Jack, Heart}, {Three, Club}, {Four, Club}, {Five, Diamond}},
+		[]Card{{Nine, Diamond}, {Nine, Club}, {Seven, Diamond}, {Seven, Club}, {Two, Diamond}},
+	)
+	ofcWeak = ofcHand(
+		[]Card{{Four, Spade}, {Three, Spade}, {Two, Spade}},
+		[]Card{{King, Diamond}, {Queen, Diamond}, {Nine, Spade}, {Seven, Spade}, {Six, Spade}},
+		[]Card{{Ace, Diamond}, {Ace, Heart}, {Eight, Club}, {Seven, Heart}, {Three, Heart}},
+	)
+)
+
+func Test_scoreFront(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     [3]Card
+		wantScore HandRank
+		wantRanks [5]Rank
+	}{
+		{
+			name:      "trips",
+			cards:     [3]Card{{Five, Spade}, {Five, Heart}, {Five, Club}},
+			wantScore: ThreeOfAKind,
+			wantRanks: [5]Rank{Five},
+		},
+		{
+			name:      "pair",
+			cards:     [3]Card{{Two, Spade}, {Queen, Heart}, {Queen, Club}},
+			wantScore: Pair,
+			wantRanks: [5]Rank{Queen, Two},
+		},
+		{
+			name:      "no straight or flush up front",
+			cards:     [3]Card{{Four, Spade}, {Three, Spade}, {Two, Spade}},
+			wantScore: HighCard,
+			wantRanks: [5]Rank{Four, Three, Two},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := scoreFront(tt.cards)
+			if got := h.handRank; got != tt.wantScore {
+				t.Errorf("scoreFront() = %v, want %v", got, tt.wantScore)
+			}
+			if got := h.ranks; got != tt.wantRanks {
+				t.Errorf("scoreFront() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+}
+
+func TestOFCHand_fouled(t *testing.T) {
+	tests := []struct {
+		name string
+		hand *OFCHand
+		want bool
+	}{
+		{name: "queens up", hand: ofcQueensUp, want: false},
+		{name: "aces over jacks", hand: ofcFouled, want: true},
+		{name: "weak", hand: ofcWeak, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hand.fouled(); got != tt.want {
+				t.Errorf("fouled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOFCHand_royalties(t *testing.T) {
+	tests := []struct {
+		name string
+		hand *OFCHand
+		want int
+	}{
+		{name: "queens, trips and a flush", hand: ofcQueensUp, want: 7 + 2 + 4},
+		{name: "fouled", hand: ofcFouled, want: 0},
+		{name: "nothing", hand: ofcWeak, want: 0},
+		{
+			name: "royal in the middle",
+			hand: ofcHand(
+				[]Card{{Two, Spade}, {Two, Heart}, {Two, Club}},
+				[]Card{{Ace, Spade}, {King, Spade}, {Queen, Spade}, {Jack, Spade}, {Ten, Spade}},
+				[]Card{{Ace, Heart}, {King, Heart}, {Queen, Heart}, {Jack, Heart}, {Ten, Heart}},
+			),
+			want: 10 + 50 + 25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hand.royalties(); got != tt.want {
+				t.Errorf("royalties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ofcScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *OFCHand
+		want int
+	}{
+		{name: "scoop with royalties", a: ofcQueensUp, b: ofcWeak, want: 6 + 13},
+		{name: "scooped", a: ofcWeak, b: ofcQueensUp, want: -(6 + 13)},
+		{name: "against a foul", a: ofcWeak, b: ofcFouled, want: 6},
+		{name: "both fouled", a: ofcFouled, b: ofcFouled, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ofcScore(tt.a, tt.b); got != tt.want {
+				t.Errorf("ofcScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	totals := ofcSettle([]*OFCHand{ofcQueensUp, ofcWeak, ofcFouled})
+	if totals[0]+totals[1]+totals[2] != 0 {
+		t.Errorf("ofcSettle() = %v, want a zero sum", totals)
+	}
+}
+
+func TestOFCHand_fantasyland(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant OFCVariant
+		hand    *OFCHand
+		want    int
+	}{
+		{name: "regular queens", variant: RegularOFC, hand: ofcQueensUp, want: 13},
+		{name: "pineapple queens", variant: PineappleOFC, hand: ofcQueensUp, want: 14},
+		{name: "progressive queens", variant: ProgressivePineappleOFC, hand: ofcQueensUp, want: 14},
+		{name: "fouled aces", variant: RegularOFC, hand: ofcFouled, want: 0},
+		{name: "no pair", variant: PineappleOFC, hand: ofcWeak, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hand.fantasyland(tt.variant); got != tt.want {
+				t.Errorf("fantasyland() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if ofcQueensUp.staysInFantasyland() {
+		t.Errorf("staysInFantasyland() = true, want false")
+	}
+}
+
+func Test_ofcDeal(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant OFCVariant
+		fantasy []int
+		wantErr bool
+	}{
+		{name: "regular 4", variant: RegularOFC, fantasy: []int{0, 0, 0, 0}},
+		{name: "pineapple 3", variant: PineappleOFC, fantasy: []int{0, 0, 0}},
+		{name: "pineapple fantasyland", variant: PineappleOFC, fantasy: []int{14, 0}},
+		{name: "pineapple 4", variant: PineappleOFC, fantasy: []int{0, 0, 0, 0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hands, err := ofcDeal(tt.variant, tt.fantasy, ofcFill)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ofcDeal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, h := range hands {
+				if !h.complete() {
+					t.Errorf("ofcDeal() left %v incomplete", h.rows)
+				}
+			}
+		})
+	}
+}
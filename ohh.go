@@ -0,0 +1,650 @@
+package poker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// The Open Hand History format writes each hand as a JSON object holding an
+// "ohh" object: the game and stakes, the players, the rounds with their
+// cards and actions, and the pots. Amounts are in currency units for cash
+// games and in chips otherwise, and an action's amount is what it put in
+// the pot. Each side pot is a pot of its own, with what every player won
+// from it and the rake it paid. Several hands in a file follow one another.
+//
+// The seed, the tournament level and the descriptions of hands shown have
+// no place in the format; showdowns are described again on the way in.
+
+const ohhSpecVersion = "1.4.7"
+
+type ohhFile struct {
+	OHH *ohhHand `json:"ohh"`
+}
+
+type ohhHand struct {
+	SpecVersion     string         `json:"spec_version"`
+	SiteName        string         `json:"site_name"`
+	NetworkName     string         `json:"network_name"`
+	InternalVersion string         `json:"internal_version"`
+	Tournament      bool           `json:"tournament"`
+	TournamentInfo  *ohhTournament `json:"tournament_info,omitempty"`
+	GameNumber      string         `json:"game_number"`
+	StartDateUTC    string         `json:"start_date_utc,omitempty"`
+	TableName       string         `json:"table_name"`
+	GameType        string         `json:"game_type"`
+	BetLimit        ohhBetLimit    `json:"bet_limit"`
+	TableSize       int            `json:"table_size"`
+	Currency        string         `json:"currency,omitempty"`
+	DealerSeat      int            `json:"dealer_seat"`
+	// stud keeps its small and big bets in the blinds
+	SmallBlind   float64     `json:"small_blind_amount"`
+	BigBlind     float64     `json:"big_blind_amount"`
+	Ante         float64     `json:"ante_amount"`
+	HeroPlayerID int         `json:"hero_player_id,omitempty"`
+	Players      []ohhPlayer `json:"players"`
+	Rounds       []ohhRound  `json:"rounds"`
+	Pots         []ohhPot    `json:"pots"`
+}
+
+type ohhTournament struct {
+	Number   string  `json:"tournament_number"`
+	Currency string  `json:"currency,omitempty"`
+	BuyIn    float64 `json:"buyin_amount,omitempty"`
+	Fee      float64 `json:"fee_amount,omitempty"`
+}
+
+type ohhBetLimit struct {
+	BetType string  `json:"bet_type"`
+	BetCap  float64 `json:"bet_cap"`
+}
+
+type ohhPlayer struct {
+	ID            int     `json:"id"`
+	Seat          int     `json:"seat"`
+	Name          string  `json:"name"`
+	Display       string  `json:"display,omitempty"`
+	StartingStack float64 `json:"starting_stack"`
+}
+
+type ohhRound struct {
+	ID      int         `json:"id"`
+	Street  string      `json:"street"`
+	Cards   []string    `json:"cards,omitempty"`
+	Actions []ohhAction `json:"actions"`
+}
+
+type ohhAction struct {
+	Number   int      `json:"action_number"`
+	PlayerID int      `json:"player_id"`
+	Action   string   `json:"action"`
+	Amount   float64  `json:"amount,omitempty"`
+	AllIn    bool     `json:"is_allin,omitempty"`
+	Cards    []string `json:"cards,omitempty"`
+}
+
+type ohhPot struct {
+	Number     int            `json:"number"`
+	Amount     float64        `json:"amount"`
+	Rake       float64        `json:"rake,omitempty"`
+	PlayerWins []ohhPlayerWin `json:"player_wins"`
+}
+
+type ohhPlayerWin struct {
+	PlayerID  int     `json:"player_id"`
+	WinAmount float64 `json:"win_amount"`
+}
+
+var ohhGames = map[string]string{"Holdem": holdemGame, "Omaha": omahaGame, "Stud": studGame}
+
+var ohhLimits = map[string]string{"NL": "No Limit", "PL": "Pot Limit", "FL": "Limit"}
+
+var ohhStreets = map[Street]string{
+	Preflop: "Preflop", Flop: "Flop", Turn: "Turn", River: "River",
+	ThirdStreet: "Third Street", FourthStreet: "Fourth Street", FifthStreet: "Fifth Street",
+	SixthStreet: "Sixth Street", SeventhStreet: "Seventh Street",
+}
+
+var ohhActions = map[ActionKind]string{
+	PostAnte: "Post Ante", PostSmallBlind: "Post SB", PostBigBlind: "Post BB", PostDead: "Post Dead",
+	PostBringIn: "Post Bring-In", Fold: "Fold", Check: "Check", Call: "Call", Bet: "Bet", Raise: "Raise",
+}
+
+// Actions that are not moves in the betting.
+const (
+	ohhDealt = "Dealt Cards"
+	ohhShows = "Shows Cards"
+	ohhMucks = "Mucks Cards"
+)
+
+// ohhIgnored are actions that change nothing in the hand.
+var ohhIgnored = map[string]bool{ohhMucks: true, "Sits Down": true, "Stands Up": true, "Added Chips": true}
+
+const ohhShowdown = "Showdown"
+
+// unknownCard stands for a card dealt face down to someone else.
+const unknownCard = "??"
+
+var buyInPattern = regexp.MustCompile(`^(\S+)\+(\S+) ([A-Z]{3})$`)
+
+// writeOHH writes a hand as an Open Hand History object. Hole cards are
+// dealt as in writePokerStars: everyone's when there is no hero.
+func writeOHH(w io.Writer, hh *HandHistory) error {
+	var gameType string
+	for name, g := range ohhGames {
+		if g == hh.Game {
+			gameType = name
+		}
+	}
+	if gameType == "" {
+		return fmt.Errorf("hand %d: Open Hand History has no %q games", hh.Number, hh.Game)
+	}
+	betType := "NL"
+	for t, limit := range ohhLimits {
+		if limit == hh.Limit {
+			betType = t
+		}
+	}
+	cash := hh.Currency != ""
+	amount := func(n int) float64 {
+		if cash {
+			return float64(n) / 100
+		}
+		return float64(n)
+	}
+
+	o := &ohhHand{
+		SpecVersion:     ohhSpecVersion,
+		SiteName:        "udacity_poker",
+		NetworkName:     "udacity_poker",
+		InternalVersion: "1",
+		Tournament:      hh.Tournament != nil,
+		GameNumber:      strconv.Itoa(hh.Number),
+		TableName:       hh.Table,
+		GameType:        gameType,
+		BetLimit:        ohhBetLimit{BetType: betType},
+		TableSize:       hh.TableSize,
+		Currency:        hh.Currency,
+		DealerSeat:      hh.Button + 1,
+		SmallBlind:      amount(hh.Small),
+		BigBlind:        amount(hh.Big),
+		Ante:            amount(hh.Ante),
+		Players:         make([]ohhPlayer, 0, len(hh.Seats)),
+		Rounds:          make([]ohhRound, 0),
+	}
+	if _, err := time.Parse(time.RFC3339, hh.Time); err == nil {
+		o.StartDateUTC = hh.Time
+	}
+	if t := hh.Tournament; t != nil {
+		o.TournamentInfo = &ohhTournament{Number: strconv.FormatInt(t.ID, 10)}
+		if m := buyInPattern.FindStringSubmatch(t.BuyIn); m != nil {
+			buyIn, err1 := parseAmount(m[1], true)
+			fee, err2 := parseAmount(m[2], true)
+			if err1 == nil && err2 == nil {
+				o.TournamentInfo.Currency = m[3]
+				o.TournamentInfo.BuyIn, o.TournamentInfo.Fee = float64(buyIn)/100, float64(fee)/100
+			}
+		}
+	}
+	for _, s := range hh.Seats {
+		o.Players = append(o.Players, ohhPlayer{ID: s.Seat + 1, Seat: s.Seat + 1, Name: s.Name, StartingStack: amount(s.Stack)})
+		if s.Name == hh.Hero {
+			o.HeroPlayerID = s.Seat + 1
+		}
+	}
+
+	number := 0
+	add := func(r *ohhRound, a ohhAction) {
+		number++
+		a.Number = number
+		r.Actions = append(r.Actions, a)
+	}
+	first, last := hh.streets()
+	i := 0
+	for street := first; street <= last; street++ {
+		r := ohhRound{ID: len(o.Rounds), Street: ohhStreets[street], Actions: make([]ohhAction, 0)}
+		if hh.Game != studGame && street >= Flop {
+			n := 3 + int(street-Flop)
+			if street == Flop {
+				r.Cards = cardStrings(hh.Board[:3])
+			} else {
+				r.Cards = cardStrings(hh.Board[n-1 : n])
+			}
+		}
+		if street == first {
+			for ; i < len(hh.Actions) && hh.Actions[i].Kind.post() && hh.Actions[i].Kind != PostBringIn; i++ {
+				a := hh.Actions[i]
+				add(&r, ohhAction{PlayerID: a.Seat + 1, Action: ohhActions[a.Kind], Amount: amount(a.Amount), AllIn: a.AllIn})
+			}
+		}
+		for _, s := range hh.Seats {
+			if cards := dealtOn(hh, &s, street, first); cards != nil {
+				add(&r, ohhAction{PlayerID: s.Seat + 1, Action: ohhDealt, Cards: cards})
+			}
+		}
+		for ; i < len(hh.Actions) && hh.Actions[i].Street == street; i++ {
+			a := hh.Actions[i]
+			add(&r, ohhAction{PlayerID: a.Seat + 1, Action: ohhActions[a.Kind], Amount: amount(a.Amount), AllIn: a.AllIn})
+		}
+		o.Rounds = append(o.Rounds, r)
+	}
+	if len(hh.Showdown) > 0 {
+		r := ohhRound{ID: len(o.Rounds), Street: ohhShowdown, Actions: make([]ohhAction, 0)}
+		for _, sd := range hh.Showdown {
+			add(&r, ohhAction{PlayerID: sd.Seat + 1, Action: ohhShows, Cards: cardStrings(hh.seat(sd.Seat).Cards)})
+		}
+		o.Rounds = append(o.Rounds, r)
+	}
+
+	o.Pots = make([]ohhPot, 0, len(hh.Pots))
+	for i, pot := range hh.Pots {
+		op := ohhPot{
+			Number:     i,
+			Amount:     amount(pot.Amount),
+			Rake:       amount(pot.Rake),
+			PlayerWins: make([]ohhPlayerWin, 0, len(pot.Awards)),
+		}
+		for _, a := range pot.Awards {
+			op.PlayerWins = append(op.PlayerWins, ohhPlayerWin{PlayerID: a.Seat + 1, WinAmount: amount(a.Amount)})
+		}
+		o.Pots = append(o.Pots, op)
+	}
+	if len(hh.Pots) == 0 {
+		o.Pots = append(o.Pots, onePot(hh, amount))
+	}
+
+	data, err := json.MarshalIndent(ohhFile{OHH: o}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n', '\n'))
+	return err
+}
+
+// onePot writes the pot of a hand that does not record its pots: the awards
+// come out of one pot and the rake is what they leave in it.
+func onePot(hh *HandHistory, amount func(int) float64) ohhPot {
+	total, won := 0, 0
+	for _, a := range hh.Actions {
+		total += a.Amount
+	}
+	pot := ohhPot{PlayerWins: make([]ohhPlayerWin, 0, len(hh.Awards))}
+	for _, a := range hh.Awards {
+		won += a.Amount
+		pot.PlayerWins = append(pot.PlayerWins, ohhPlayerWin{PlayerID: a.Seat + 1, WinAmount: amount(a.Amount)})
+	}
+	if total > won {
+		pot.Rake = amount(total - won)
+	} else {
+		total = won
+	}
+	pot.Amount = amount(total)
+	return pot
+}
+
+func cardStrings(cards []Card) []string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = formatCards([]Card{c})
+	}
+	return s
+}
+
+// dealtOn returns the cards a seat is dealt on a street, or nil. In stud the
+// others' face-down cards are unknown.
+func dealtOn(hh *HandHistory, s *SeatRecord, street, first Street) []string {
+	known := hh.Hero == "" || hh.Hero == s.Name
+	if hh.Game != studGame {
+		if street == first && known && len(s.Cards) > 0 {
+			return cardStrings(s.Cards)
+		}
+		return nil
+	}
+	n := 3 + int(street-ThirdStreet)
+	switch up := n - 2; {
+	case known && len(s.Cards) >= n && street == ThirdStreet:
+		return cardStrings(s.Cards[:3])
+	case known && len(s.Cards) >= n:
+		return cardStrings(s.Cards[n-1 : n])
+	case len(s.Up) == 0:
+		return nil
+	case street == ThirdStreet:
+		return []string{unknownCard, unknownCard, cardStrings(s.Up[:1])[0]}
+	case street == SeventhStreet:
+		return []string{unknownCard}
+	case len(s.Up) >= up:
+		return cardStrings(s.Up[up-1 : up])
+	}
+	return nil
+}
+
+// readOHH reads every hand in an Open Hand History file. Errors name the
+// hand and the field at fault, such as ohh.rounds[1].actions[0].player_id.
+func readOHH(r io.Reader) ([]*HandHistory, error) {
+	histories := make([]*HandHistory, 0)
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var f ohhFile
+		err := dec.Decode(&f)
+		if err == io.EOF {
+			return histories, nil
+		}
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr):
+			return nil, fmt.Errorf("ohh hand %d: %s: JSON %s is not a %s", n, typeErr.Field, typeErr.Value, typeErr.Type)
+		case err != nil:
+			return nil, fmt.Errorf("ohh hand %d: %w", n, err)
+		case f.OHH == nil:
+			return nil, fmt.Errorf("ohh hand %d: no ohh object", n)
+		}
+		hh, err := f.OHH.history()
+		if err != nil {
+			return nil, fmt.Errorf("ohh hand %d: %w", n, err)
+		}
+		histories = append(histories, hh)
+	}
+}
+
+// ohhReader maps one hand onto a history.
+type ohhReader struct {
+	hh   *HandHistory
+	cash bool
+	// seats maps player ids to their place in hh.Seats
+	seats map[int]int
+	bets  map[int]int
+	// hidden are the stud seats dealt unknown cards, whose known ones are
+	// face up
+	hidden map[int]bool
+}
+
+func (o *ohhHand) history() (*HandHistory, error) {
+	game, ok := ohhGames[o.GameType]
+	if !ok {
+		return nil, fmt.Errorf("ohh.game_type: unsupported game %q", o.GameType)
+	}
+	limit, ok := ohhLimits[o.BetLimit.BetType]
+	if !ok {
+		return nil, fmt.Errorf("ohh.bet_limit.bet_type: unknown bet type %q", o.BetLimit.BetType)
+	}
+	number, err := strconv.Atoi(o.GameNumber)
+	if err != nil {
+		return nil, fmt.Errorf("ohh.game_number: %q is not a number", o.GameNumber)
+	}
+	if o.StartDateUTC != "" {
+		if _, err := time.Parse(time.RFC3339, o.StartDateUTC); err != nil {
+			return nil, fmt.Errorf("ohh.start_date_utc: %q is not an RFC 3339 time", o.StartDateUTC)
+		}
+	}
+	if o.TableSize < 2 {
+		return nil, fmt.Errorf("ohh.table_size: %d seats", o.TableSize)
+	}
+	if o.DealerSeat < 0 || o.DealerSeat > o.TableSize {
+		return nil, fmt.Errorf("ohh.dealer_seat: no seat %d at a table of %d", o.DealerSeat, o.TableSize)
+	}
+
+	p := &ohhReader{
+		hh: &HandHistory{
+			Game: game, Limit: limit, Number: number, Table: o.TableName, Time: o.StartDateUTC,
+			Currency: o.Currency, TableSize: o.TableSize, Button: o.DealerSeat - 1,
+		},
+		cash:   o.Currency != "",
+		seats:  make(map[int]int),
+		bets:   make(map[int]int),
+		hidden: make(map[int]bool),
+	}
+	hh := p.hh
+	if o.Tournament {
+		hh.Tournament = &TournamentRecord{}
+		if t := o.TournamentInfo; t != nil {
+			if hh.Tournament.ID, err = strconv.ParseInt(t.Number, 10, 64); err != nil {
+				return nil, fmt.Errorf("ohh.tournament_info.tournament_number: %q is not a number", t.Number)
+			}
+			if t.Currency != "" {
+				buyIn, err := amountOf("ohh.tournament_info.buyin_amount", t.BuyIn, true)
+				if err != nil {
+					return nil, err
+				}
+				fee, err := amountOf("ohh.tournament_info.fee_amount", t.Fee, true)
+				if err != nil {
+					return nil, err
+				}
+				hh.Tournament.BuyIn = fmt.Sprintf("%s+%s %s", buyInAmount(buyIn, t.Currency), buyInAmount(fee, t.Currency), t.Currency)
+			}
+		}
+	}
+	for _, f := range []struct {
+		path  string
+		value float64
+		to    *int
+	}{
+		{"ohh.small_blind_amount", o.SmallBlind, &hh.Small},
+		{"ohh.big_blind_amount", o.BigBlind, &hh.Big},
+		{"ohh.ante_amount", o.Ante, &hh.Ante},
+	} {
+		if *f.to, err = p.amount(f.path, f.value); err != nil {
+			return nil, err
+		}
+	}
+
+	taken := make(map[int]bool)
+	for i, pl := range o.Players {
+		path := fmt.Sprintf("ohh.players[%d]", i)
+		switch _, dup := p.seats[pl.ID]; {
+		case dup:
+			return nil, fmt.Errorf("%s.id: player %d appears twice", path, pl.ID)
+		case pl.Seat < 1 || pl.Seat > o.TableSize:
+			return nil, fmt.Errorf("%s.seat: no seat %d at a table of %d", path, pl.Seat, o.TableSize)
+		case taken[pl.Seat]:
+			return nil, fmt.Errorf("%s.seat: seat %d is taken", path, pl.Seat)
+		case pl.Name == "":
+			return nil, fmt.Errorf("%s.name: missing", path)
+		}
+		stack, err := p.amount(path+".starting_stack", pl.StartingStack)
+		if err != nil {
+			return nil, err
+		}
+		taken[pl.Seat] = true
+		p.seats[pl.ID] = len(hh.Seats)
+		hh.Seats = append(hh.Seats, SeatRecord{Seat: pl.Seat - 1, Name: pl.Name, Stack: stack})
+	}
+	if o.HeroPlayerID != 0 {
+		i, ok := p.seats[o.HeroPlayerID]
+		if !ok {
+			return nil, fmt.Errorf("ohh.hero_player_id: no player %d", o.HeroPlayerID)
+		}
+		hh.Hero = hh.Seats[i].Name
+	}
+
+	for i, r := range o.Rounds {
+		if err := p.round(fmt.Sprintf("ohh.rounds[%d]", i), r); err != nil {
+			return nil, err
+		}
+	}
+
+	won := make(map[int]int)
+	for i, pot := range o.Pots {
+		path := fmt.Sprintf("ohh.pots[%d]", i)
+		size, err := p.amount(path+".amount", pot.Amount)
+		if err != nil {
+			return nil, err
+		}
+		rake, err := p.amount(path+".rake", pot.Rake)
+		if err != nil {
+			return nil, err
+		}
+		rec := PotRecord{Amount: size, Rake: rake, Awards: make([]Award, 0, len(pot.PlayerWins))}
+		paid := rake
+		for j, w := range pot.PlayerWins {
+			wpath := fmt.Sprintf("%s.player_wins[%d]", path, j)
+			seat, err := p.seat(wpath+".player_id", w.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+			n, err := p.amount(wpath+".win_amount", w.WinAmount)
+			if err != nil {
+				return nil, err
+			}
+			paid += n
+			won[seat.Seat] += n
+			rec.Awards = append(rec.Awards, Award{Seat: seat.Seat, Amount: n})
+		}
+		if paid != size {
+			return nil, fmt.Errorf("%s.player_wins: %d won and %d raked from a pot of %d", path, paid-rake, rake, size)
+		}
+		hh.Pots = append(hh.Pots, rec)
+	}
+	for _, s := range hh.Seats {
+		if won[s.Seat] > 0 {
+			hh.Awards = append(hh.Awards, Award{Seat: s.Seat, Amount: won[s.Seat]})
+		}
+	}
+
+	for i, sd := range hh.Showdown {
+		if v, ok := bestValue(hh.Game, hh.seat(sd.Seat).Cards, hh.Board); ok {
+			hh.Showdown[i].Category, hh.Showdown[i].Description = v.handRank(), v.describe()
+		}
+	}
+	return hh, nil
+}
+
+// amount converts an amount to cents for cash games and chips otherwise.
+func (p *ohhReader) amount(path string, x float64) (int, error) {
+	return amountOf(path, x, p.cash)
+}
+
+func amountOf(path string, x float64, cash bool) (int, error) {
+	scaled, unit := x, "chips"
+	if cash {
+		scaled, unit = x*100, "cents"
+	}
+	n := math.Round(scaled)
+	if x < 0 || math.Abs(scaled-n) > 1e-6 || n > math.MaxInt32 {
+		return 0, fmt.Errorf("%s: %v is not a whole number of %s", path, x, unit)
+	}
+	return int(n), nil
+}
+
+// buyInAmount writes a buy-in the way PokerStars does, with the cents.
+func buyInAmount(cents int, currency string) string {
+	return fmt.Sprintf("%s%d.%02d", currencySymbols[currency], cents/100, cents%100)
+}
+
+func (p *ohhReader) seat(path string, id int) (*SeatRecord, error) {
+	i, ok := p.seats[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: no player %d", path, id)
+	}
+	return &p.hh.Seats[i], nil
+}
+
+func (p *ohhReader) round(path string, r ohhRound) error {
+	hh := p.hh
+	street, showdown := Street(-1), r.Street == ohhShowdown
+	for s, name := range ohhStreets {
+		if name == r.Street && (s >= ThirdStreet) == (hh.Game == studGame) {
+			street = s
+		}
+	}
+	if street < 0 && !showdown {
+		return fmt.Errorf("%s.street: no %q street in %s", path, r.Street, hh.Game)
+	}
+	cards, _, err := ohhCardList(path+".cards", r.Cards, false)
+	if err != nil {
+		return err
+	}
+	hh.Board = append(hh.Board, cards...)
+	p.bets = make(map[int]int)
+
+	for i, a := range r.Actions {
+		apath := fmt.Sprintf("%s.actions[%d]", path, i)
+		seat, err := p.seat(apath+".player_id", a.PlayerID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ohhIgnored[a.Action]:
+			continue
+		case a.Action == ohhDealt:
+			if err := p.deal(apath+".cards", seat, a.Cards); err != nil {
+				return err
+			}
+			continue
+		case a.Action == ohhShows:
+			shown, _, err := ohhCardList(apath+".cards", a.Cards, false)
+			if err != nil {
+				return err
+			}
+			seat.Cards = shown
+			hh.Showdown = append(hh.Showdown, ShowdownRecord{Seat: seat.Seat})
+			continue
+		case showdown:
+			return fmt.Errorf("%s.action: %q at the showdown", apath, a.Action)
+		}
+
+		kind := ActionKind(-1)
+		for k, name := range ohhActions {
+			if name == a.Action {
+				kind = k
+			}
+		}
+		if kind < 0 {
+			return fmt.Errorf("%s.action: unsupported action %q", apath, a.Action)
+		}
+		n, err := p.amount(apath+".amount", a.Amount)
+		if err != nil {
+			return err
+		}
+		act := Action{Street: street, Seat: seat.Seat, Kind: kind, Amount: n, AllIn: a.AllIn}
+		if kind == Bet || kind == Raise {
+			act.To = p.bets[seat.Seat] + n
+		}
+		if kind != PostAnte && kind != PostDead {
+			p.bets[seat.Seat] += n
+		}
+		hh.Actions = append(hh.Actions, act)
+	}
+	return nil
+}
+
+// deal reads dealt cards. A stud seat dealt unknown cards is someone else's,
+// whose known cards are face up.
+func (p *ohhReader) deal(path string, seat *SeatRecord, cards []string) error {
+	dealt, hidden, err := ohhCardList(path, cards, p.hh.Game == studGame)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		p.hidden[seat.Seat] = true
+	}
+	if p.hidden[seat.Seat] {
+		seat.Up = append(seat.Up, dealt...)
+	} else {
+		seat.Cards = append(seat.Cards, dealt...)
+	}
+	return nil
+}
+
+// ohhCardList parses cards, skipping unknown ones when they are allowed and
+// reporting whether there were any.
+func ohhCardList(path string, cards []string, unknown bool) ([]Card, bool, error) {
+	var parsed []Card
+	hidden := false
+	for i, s := range cards {
+		if s == unknownCard && unknown {
+			hidden = true
+			continue
+		}
+		var c Card
+		if err := c.UnmarshalText([]byte(s)); err != nil {
+			return nil, false, fmt.Errorf("%s[%d]: %w", path, i, err)
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed, hidden, nil
+}
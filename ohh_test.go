@@ -0,0 +1,230 @@
+package poker
+
+import (
+	"strings"
+	"testing"
+)
+
+const ohhSample = `{"ohh": {
+  "spec_version": "1.4.7",
+  "site_name": "Test Site",
+  "network_name": "Test Network",
+  "internal_version": "1.0",
+  "tournament": false,
+  "game_number": "42",
+  "start_date_utc": "2020-01-02T17:34:56Z",
+  "table_name": "Andromeda V",
+  "game_type": "Holdem",
+  "bet_limit": {"bet_type": "NL", "bet_cap": 0},
+  "table_size": 6,
+  "currency": "USD",
+  "dealer_seat": 2,
+  "small_blind_amount": 0.01,
+  "big_blind_amount": 0.02,
+  "ante_amount": 0,
+  "hero_player_id": 7,
+  "flags": [],
+  "players": [
+    {"id": 7, "seat": 1, "name": "alice", "display": "alice", "starting_stack": 2, "player_bounty": 0, "is_sitting_out": false},
+    {"id": 3, "seat": 2, "name": "bob", "starting_stack": 1.5},
+    {"id": 9, "seat": 3, "name": "carol", "starting_stack": 2.13}
+  ],
+  "rounds": [
+    {"id": 0, "street": "Preflop", "cards": [], "actions": [
+      {"action_number": 1, "player_id": 9, "action": "Post SB", "amount": 0.01, "is_allin": false},
+      {"action_number": 2, "player_id": 7, "action": "Post BB", "amount": 0.02},
+      {"action_number": 3, "player_id": 7, "action": "Dealt Cards", "cards": ["Ah", "Kh"]},
+      {"action_number": 4, "player_id": 3, "action": "Raise", "amount": 0.06},
+      {"action_number": 5, "player_id": 9, "action": "Fold", "amount": 0},
+      {"action_number": 6, "player_id": 7, "action": "Call", "amount": 0.04}
+    ]},
+    {"id": 1, "street": "Flop", "cards": ["2h", "7h", "Jc"], "actions": [
+      {"action_number": 7, "player_id": 7, "action": "Bet", "amount": 0.1},
+      {"action_number": 8, "player_id": 3, "action": "Call", "amount": 0.1}
+    ]},
+    {"id": 2, "street": "Turn", "cards": ["Qh"], "actions": [
+      {"action_number": 9, "player_id": 7, "action": "Check"},
+      {"action_number": 10, "player_id": 3, "action": "Check"}
+    ]},
+    {"id": 3, "street": "River", "cards": ["3c"], "actions": [
+      {"action_number": 11, "player_id": 7, "action": "Check"},
+      {"action_number": 12, "player_id": 3, "action": "Check"}
+    ]},
+    {"id": 4, "street": "Showdown", "actions": [
+      {"action_number": 13, "player_id": 7, "action": "Shows Cards", "cards": ["Ah", "Kh"]},
+      {"action_number": 14, "player_id": 3, "action": "Mucks Cards"}
+    ]}
+  ],
+  "pots": [{"number": 0, "amount": 0.33, "rake": 0.01, "jackpot": 0,
+    "player_wins": [{"player_id": 7, "win_amount": 0.32, "cashout_amount": 0, "cashout_fee": 0}]}]
+}}
+`
+
+func Test_readOHH(t *testing.T) {
+	hands, err := readOHH(strings.NewReader(ohhSample + "\n" + ohhSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("read %d hands, want 2", len(hands))
+	}
+	hh := hands[0]
+	if hh.Game != holdemGame || hh.Limit != "No Limit" || hh.Number != 42 || hh.Button != 1 || hh.Hero != "alice" ||
+		hh.Small != 1 || hh.Big != 2 || hh.Time != "2020-01-02T17:34:56Z" || hh.Table != "Andromeda V" {
+		t.Errorf("header = %+v", hh)
+	}
+	for _, check := range []struct {
+		name string
+		got  any
+		want string
+	}{
+		{"seats", hh.Seats, `[{"seat":0,"name":"alice","stack":200,"cards":["Ah","Kh"]},{"seat":1,"name":"bob","stack":150},{"seat":2,"name":"carol","stack":213}]`},
+		{"raise", hh.Actions[2], `{"street":"Preflop","seat":1,"kind":"Raise","amount":6,"to":6}`},
+		{"bet", hh.Actions[5], `{"street":"Flop","seat":0,"kind":"Bet","amount":10,"to":10}`},
+		{"board", hh.Board, `["2h","7h","Jc","Qh","3c"]`},
+		{"showdown", hh.Showdown, `[{"seat":0,"category":"Flush","description":"` + evaluate(append(hh.Seats[0].Cards, hh.Board...)).describe() + `"}]`},
+		{"awards", hh.Awards, `[{"seat":0,"amount":32}]`},
+		{"pots", hh.Pots, `[{"amount":33,"rake":1,"awards":[{"seat":0,"amount":32}]}]`},
+	} {
+		if got := toJSON(t, check.got); got != check.want {
+			t.Errorf("%s = %s, want %s", check.name, got, check.want)
+		}
+	}
+}
+
+func Test_readOHH_errors(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     string
+	}{
+		{`"game_type": "Holdem"`, `"game_type": "Draw"`, "ohh.game_type"},
+		{`"bet_type": "NL"`, `"bet_type": "XL"`, "ohh.bet_limit.bet_type"},
+		{`"game_number": "42"`, `"game_number": "42a"`, "ohh.game_number"},
+		{`"start_date_utc": "2020-01-02T17:34:56Z"`, `"start_date_utc": "2020/01/02"`, "ohh.start_date_utc"},
+		{`"table_size": 6`, `"table_size": "6"`, "table_size"},
+		{`"hero_player_id": 7`, `"hero_player_id": 8`, "ohh.hero_player_id"},
+		{`"seat": 2,`, `"seat": 9,`, "ohh.players[1].seat"},
+		{`"seat": 2,`, `"seat": 1,`, "ohh.players[1].seat"},
+		{`"id": 3,`, `"id": 7,`, "ohh.players[1].id"},
+		{`"starting_stack": 2.13`, `"starting_stack": 2.135`, "ohh.players[2].starting_stack"},
+		{`"player_id": 3, "action": "Raise"`, `"player_id": 4, "action": "Raise"`, "ohh.rounds[0].actions[3].player_id"},
+		{`"action": "Fold"`, `"action": "Straddle"`, "ohh.rounds[0].actions[4].action"},
+		{`"amount": 0.04`, `"amount": -0.04`, "ohh.rounds[0].actions[5].amount"},
+		{`"street": "Turn"`, `"street": "Third Street"`, "ohh.rounds[2].street"},
+		{`["Qh"]`, `["Q"]`, "ohh.rounds[2].cards[0]"},
+		{`"Shows Cards", "cards": ["Ah", "Kh"]`, `"Shows Cards", "cards": ["Ah", "??"]`, "ohh.rounds[4].actions[0].cards[1]"},
+		{`"win_amount": 0.32`, `"win_amount": 0.34`, "ohh.pots[0].player_wins"},
+		{`"win_amount": 0.32`, `"win_amount": 0.31`, "ohh.pots[0].player_wins"},
+		{`"rake": 0.01`, `"rake": 0.02`, "ohh.pots[0].player_wins"},
+		{`"rake": 0.01`, `"rake": -0.01`, "ohh.pots[0].rake"},
+		{`"player_id": 7, "win_amount"`, `"player_id": 1, "win_amount"`, "ohh.pots[0].player_wins[0].player_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			text := strings.Replace(ohhSample, tt.old, tt.new, 1)
+			if text == ohhSample {
+				t.Fatalf("sample has no %s", tt.old)
+			}
+			_, err := readOHH(strings.NewReader(text))
+			if err == nil || !strings.HasPrefix(err.Error(), "ohh hand 1: ") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("readOHH() error = %v, want one naming %s", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := readOHH(strings.NewReader(`{"hand": {}}`)); err == nil {
+		t.Error("readOHH() of an object without ohh succeeded")
+	}
+	if _, err := readOHH(strings.NewReader(ohhSample + `{"ohh": `)); err == nil || !strings.HasPrefix(err.Error(), "ohh hand 2: ") {
+		t.Errorf("readOHH() of a truncated second hand: error = %v", err)
+	}
+}
+
+func Test_writeOHH(t *testing.T) {
+	read := func(hh *HandHistory) *HandHistory {
+		t.Helper()
+		var b strings.Builder
+		if err := writeOHH(&b, hh); err != nil {
+			t.Fatal(err)
+		}
+		again, err := readOHH(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("hand %d: %v\n%s", hh.Number, err, b.String())
+		}
+		return again[0]
+	}
+	categories := func(hh *HandHistory) []HandRank {
+		var c []HandRank
+		for _, sd := range hh.Showdown {
+			c = append(c, sd.Category)
+		}
+		return c
+	}
+
+	stars, err := readPokerStars(strings.NewReader(starsHands))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ohh, err := readOHH(strings.NewReader(ohhSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulated, _ := recordHoldem(t, 5, 30)
+
+	for _, hh := range append(append(stars, ohh...), simulated...) {
+		got := read(hh)
+		for _, check := range []struct {
+			name      string
+			got, want any
+		}{
+			{"header", []any{got.Game, got.Number, got.TableSize, got.Small, got.Big, got.Ante, got.Button, got.Currency, got.Hero},
+				[]any{hh.Game, hh.Number, hh.TableSize, hh.Small, hh.Big, hh.Ante, hh.Button, hh.Currency, hh.Hero}},
+			{"seats", got.Seats, hh.Seats},
+			{"board", got.Board, hh.Board},
+			{"actions", got.Actions, hh.Actions},
+			{"showdown", categories(got), categories(hh)},
+			{"awards", got.Awards, hh.Awards},
+		} {
+			if g, w := toJSON(t, check.got), toJSON(t, check.want); g != w {
+				t.Errorf("hand %d %s = %s, want %s", hh.Number, check.name, g, w)
+			}
+		}
+	}
+
+	// an all-in hand writes a pot for each side pot
+	sidePots := 0
+	for _, hh := range simulated {
+		if len(hh.Pots) < 2 {
+			continue
+		}
+		sidePots++
+		var b strings.Builder
+		if err := writeOHH(&b, hh); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(b.String(), `"player_wins"`); got != len(hh.Pots) {
+			t.Errorf("hand %d wrote %d pots, want %d", hh.Number, got, len(hh.Pots))
+		}
+		if g, w := toJSON(t, read(hh).Pots), toJSON(t, hh.Pots); g != w {
+			t.Errorf("hand %d pots = %s, want %s", hh.Number, g, w)
+		}
+	}
+	if sidePots == 0 {
+		t.Error("no simulated hand has a side pot")
+	}
+
+	// hands written by the engine come back whole but for the seed
+	got := read(simulated[0])
+	got.Seed, got.Limit = simulated[0].Seed, ""
+	if g, w := toJSON(t, got), toJSON(t, simulated[0]); g != w {
+		t.Errorf("simulated hand = %s, want %s", g, w)
+	}
+	if got := read(stars[1]).Tournament; got == nil || got.ID != 3000000001 || got.BuyIn != "$1.00+$0.10 USD" {
+		t.Errorf("tournament = %+v", got)
+	}
+
+	var b strings.Builder
+	if err := writeOHH(&b, &HandHistory{Game: fiveCardGame}); err == nil {
+		t.Error("writeOHH() of a five-card hand succeeded, want an error")
+	}
+}
@@ -0,0 +1,177 @@
+package poker
+
+import "math/bits"
+
+// Draw is a way an unmade hand can still come in, with the cards that make
+// it. A backdoor draw lists the cards that turn it into a draw on the turn.
+type Draw struct {
+	kind DrawKind
+	outs []Card
+}
+
+// unseen returns the cards in neither the hand, the board nor the dead cards,
+// in deck order.
+func unseen(hole, board, dead []Card) []Card {
+	seen := make(map[Card]bool)
+	for _, cards := range [][]Card{hole, board, dead} {
+		for _, c := range cards {
+			seen[c] = true
+		}
+	}
+	cards := make([]Card, 0, 52-len(seen))
+	for _, c := range deck() {
+		if !seen[c] {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// outs lists, for every category the hand can improve to with the next card,
+// the unseen cards that take it there. A card that gives the board the same
+// category on its own, such as one pairing the board, is not an out.
+func outs(hole, board, dead []Card) map[HandRank][]Card {
+	cards := append(append([]Card{}, hole...), board...)
+	now := evaluate(cards).handRank()
+	improved := make(map[HandRank][]Card)
+	for _, c := range unseen(hole, board, dead) {
+		r := evaluate(append(cards, c)).handRank()
+		if r > now && r > evaluate(append(append([]Card{}, board...), c)).handRank() {
+			improved[r] = append(improved[r], c)
+		}
+	}
+	return improved
+}
+
+// rankBit is the bit of a rank in a suit mask.
+func rankBit(r Rank) uint16 {
+	return 1 << (r - 2)
+}
+
+// flushDraws finds four to a flush, or three on the flop, with at least one
+// of the suit in the hand. Like flush it looks at one suit at a time.
+func flushDraws(hole, board []Card, left []Card) []Draw {
+	draws := make([]Draw, 0)
+	held, all := masksOf(hole), masksOf(append(append([]Card{}, hole...), board...))
+	for s := range all {
+		n := bits.OnesCount16(all[s])
+		kind := FlushDraw
+		switch {
+		case held[s] == 0:
+			continue
+		case n == 4:
+		case n == 3 && len(board) == 3:
+			kind = BackdoorFlush
+		default:
+			continue
+		}
+		d := Draw{kind: kind}
+		for _, c := range left {
+			if c.suit == Suit(s) {
+				d.outs = append(d.outs, c)
+			}
+		}
+		draws = append(draws, d)
+	}
+	return draws
+}
+
+// completes returns the ranks that would give the hand a straight it does
+// not have, higher than any straight the board makes with the same card.
+func completes(hand, board uint16) []Rank {
+	ranks := make([]Rank, 0, 2)
+	if straightHigh(hand) != 0 {
+		return ranks
+	}
+	for r := Two; r <= Ace; r++ {
+		if high := straightHigh(hand | rankBit(r)); high != 0 && high > straightHigh(board|rankBit(r)) {
+			ranks = append(ranks, r)
+		}
+	}
+	return ranks
+}
+
+// straightDraws finds open-ended straight draws, counting double gutshots as
+// open-ended since they also have two ranks to hit, gutshots and, on the
+// flop, backdoor straight draws.
+func straightDraws(hole, board []Card, left []Card) []Draw {
+	hm, bm := masksOf(hole), masksOf(board)
+	hand := hm[0] | hm[1] | hm[2] | hm[3] | bm[0] | bm[1] | bm[2] | bm[3]
+	onBoard := bm[0] | bm[1] | bm[2] | bm[3]
+
+	withRanks := func(ranks []Rank) []Card {
+		cards := make([]Card, 0)
+		for _, c := range left {
+			for _, r := range ranks {
+				if c.rank == r {
+					cards = append(cards, c)
+				}
+			}
+		}
+		return cards
+	}
+	draws := make([]Draw, 0)
+	switch ranks := completes(hand, onBoard); {
+	case len(ranks) >= 2:
+		draws = append(draws, Draw{kind: OpenEnded, outs: withRanks(ranks)})
+	case len(ranks) == 1:
+		draws = append(draws, Draw{kind: Gutshot, outs: withRanks(ranks)})
+	case len(board) == 3 && straightHigh(hand) == 0:
+		// a backdoor draw needs a turn card that leaves a straight draw
+		turns := make([]Rank, 0)
+		for r := Two; r <= Ace; r++ {
+			if hand&rankBit(r) == 0 && len(completes(hand|rankBit(r), onBoard|rankBit(r))) > 0 {
+				turns = append(turns, r)
+			}
+		}
+		if len(turns) > 0 {
+			draws = append(draws, Draw{kind: BackdoorStraight, outs: withRanks(turns)})
+		}
+	}
+	return draws
+}
+
+// draws lists the draws a hand has on the flop or turn: flush and straight
+// draws to a better hand than it holds, backdoor draws on the flop and, with
+// no pair, hole cards above the board. Dead cards, such as those folded face
+// up, are not outs. On the river there are none.
+func draws(hole, board, dead []Card) []Draw {
+	if len(board) >= 5 {
+		return []Draw{}
+	}
+	left := unseen(hole, board, dead)
+	made := evaluate(append(append([]Card{}, hole...), board...)).handRank()
+	found := make([]Draw, 0)
+	if made < Flush {
+		found = append(found, flushDraws(hole, board, left)...)
+	}
+	if made < Straight {
+		found = append(found, straightDraws(hole, board, left)...)
+	}
+
+	if made != HighCard {
+		return found
+	}
+	top := Two
+	for _, c := range board {
+		if c.rank > top {
+			top = c.rank
+		}
+	}
+	over := Draw{kind: Overcards}
+	for _, h := range hole {
+		if h.rank <= top {
+			continue
+		}
+		for _, c := range left {
+			if c.rank == h.rank {
+				over.outs = append(over.outs, c)
+			}
+		}
+	}
+	if len(over.outs) > 0 {
+		found = append(found, over)
+	}
+
+	return found
+}
@@ -0,0 +1,94 @@
+package poker
+
+import "testing"
+
+func Test_draws(t *testing.T) {
+	tests := []struct {
+		name  string
+		hole  []Card
+		board []Card
+		dead  []Card
+		want  map[DrawKind]int
+	}{
+		{
+			name:  "nut flush draw with overcards",
+			hole:  []Card{{Ace, Heart}, {King, Heart}},
+			board: []Card{{Queen, Heart}, {Seven, Heart}, {Two, Club}},
+			want:  map[DrawKind]int{FlushDraw: 9, BackdoorStraight: 8, Overcards: 6},
+		},
+		{
+			name:  "open-ended",
+			hole:  []Card{{Nine, Spade}, {Eight, Diamond}},
+			board: []Card{{Seven, Club}, {Six, Heart}, {Two, Spade}, {King, Club}},
+			want:  map[DrawKind]int{OpenEnded: 8},
+		},
+		{
+			name:  "gutshot with a dead out",
+			hole:  []Card{{Nine, Spade}, {Eight, Diamond}},
+			board: []Card{{Seven, Club}, {Five, Heart}, {King, Spade}},
+			dead:  []Card{{Six, Club}},
+			want:  map[DrawKind]int{Gutshot: 3},
+		},
+		{
+			name:  "double gutshot",
+			hole:  []Card{{Nine, Spade}, {Seven, Diamond}},
+			board: []Card{{Jack, Club}, {Five, Heart}, {Eight, Club}, {Two, Diamond}},
+			want:  map[DrawKind]int{OpenEnded: 8},
+		},
+		{
+			name:  "backdoor draws",
+			hole:  []Card{{Ace, Heart}, {Ten, Heart}},
+			board: []Card{{Queen, Heart}, {Seven, Club}, {Two, Diamond}},
+			want:  map[DrawKind]int{BackdoorFlush: 10, BackdoorStraight: 8, Overcards: 3},
+		},
+		{
+			name:  "straight draw on the board only",
+			hole:  []Card{{Two, Heart}, {Two, Club}},
+			board: []Card{{Nine, Spade}, {Eight, Diamond}, {Seven, Club}, {Six, Heart}},
+			want:  map[DrawKind]int{},
+		},
+		{
+			name:  "made flush",
+			hole:  []Card{{Ace, Heart}, {Ten, Heart}},
+			board: []Card{{Queen, Heart}, {Seven, Heart}, {Two, Heart}},
+			want:  map[DrawKind]int{},
+		},
+		{
+			name:  "river",
+			hole:  []Card{{Ace, Heart}, {King, Heart}},
+			board: []Card{{Queen, Heart}, {Seven, Heart}, {Two, Club}, {Nine, Spade}, {Four, Diamond}},
+			want:  map[DrawKind]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[DrawKind]int)
+			for _, d := range draws(tt.hole, tt.board, tt.dead) {
+				got[d.kind] = len(d.outs)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("draws() = %v, want %v", got, tt.want)
+			}
+			for k, n := range tt.want {
+				if got[k] != n {
+					t.Errorf("%s outs = %d, want %d", k, got[k], n)
+				}
+			}
+		})
+	}
+}
+
+func Test_outs(t *testing.T) {
+	hole := []Card{{Ace, Heart}, {King, Heart}}
+	board := []Card{{Queen, Heart}, {Jack, Club}, {Two, Heart}}
+	got := outs(hole, board, []Card{{Ten, Club}})
+	want := map[HandRank]int{Pair: 6, Straight: 2, Flush: 9}
+	if len(got) != len(want) {
+		t.Errorf("outs() = %v, want %v", got, want)
+	}
+	for r, n := range want {
+		if len(got[r]) != n {
+			t.Errorf("%s outs = %v, want %d of them", r, got[r], n)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// joker is the fifty-third card of a Pai Gow deck. It plays as a bug: an
+// ace, or any card that completes a straight, flush or straight flush.
+var joker = Card{suit: -1}
+
+// fiveAces is four aces and the joker, which beats a royal flush.
+const fiveAces = StraightFlush + 1
+
+// PaiGowSetting splits seven cards into a five-card high hand and a two-card
+// low hand.
+type PaiGowSetting struct {
+	high [5]Card
+	low  [2]Card
+}
+
+func paiGowDeck() []Card {
+	return append(deck(), joker)
+}
+
+// paiGowDeal deals seven cards to every hand from the 53-card deck.
+func paiGowDeal(numHands int) ([][7]Card, error) {
+	cards := paiGowDeck()
+	if 7*numHands > len(cards) {
+		return nil, fmt.Errorf("not enough cards in the deck")
+	}
+	shuffle(cards)
+
+	hands := make([][7]Card, numHands)
+	for i := range hands {
+		hands[i] = [7]Card(cards[i*7 : (i+1)*7])
+	}
+
+	return hands, nil
+}
+
+// paiGowWheel puts A-2-3-4-5 between the ace-high and king-high straights.
+// The ranks sort above K-Q-J-T-9 and below A-K-Q-J-T.
+var paiGowWheel = [5]Rank{Ace, King, Queen, Jack, Nine}
+
+func paiGowAdjust(v handValue) handValue {
+	r := v.handRank()
+	if (r == Straight || r == StraightFlush) && v.ranks()[0] == Five {
+		return packValue(r, paiGowWheel)
+	}
+	return v
+}
+
+// paiGowHigh values a five-card hand, playing the joker as its best bug.
+func paiGowHigh(cards [5]Card) handValue {
+	at := -1
+	aces := 0
+	used := make(map[Card]bool)
+	for i, c := range cards {
+		if c == joker {
+			at = i
+		}
+		if c.rank == Ace {
+			aces++
+		}
+		used[c] = true
+	}
+	if at < 0 {
+		return paiGowAdjust(evaluate(cards[:]))
+	}
+	if aces == 4 {
+		return packValue(fiveAces, [5]Rank{Ace})
+	}
+
+	var best handValue
+	for _, c := range deck() {
+		if used[c] {
+			continue
+		}
+		cards[at] = c
+		v := evaluate(cards[:])
+		if r := v.handRank(); c.rank != Ace && r != Straight && r != Flush && r != StraightFlush {
+			continue
+		}
+		if v = paiGowAdjust(v); v > best {
+			best = v
+		}
+	}
+
+	return best
+}
+
+// paiGowLow values a two-card hand on the five-card scale, so it compares
+// directly with a high hand. The joker is an ace.
+func paiGowLow(cards [2]Card) handValue {
+	a, b := cards[0].rank, cards[1].rank
+	if cards[0] == joker {
+		a = Ace
+	}
+	if cards[1] == joker {
+		b = Ace
+	}
+	if a < b {
+		a, b = b, a
+	}
+	if a == b {
+		return packValue(Pair, [5]Rank{a})
+	}
+	return packValue(HighCard, [5]Rank{a, b})
+}
+
+func (s PaiGowSetting) fouled() bool {
+	return paiGowLow(s.low) >= paiGowHigh(s.high)
+}
+
+// paiGowSettings lists the 21 ways to set seven cards.
+func paiGowSettings(cards [7]Card) []PaiGowSetting {
+	settings := make([]PaiGowSetting, 0, 21)
+	for i := 0; i < 7; i++ {
+		for j := i + 1; j < 7; j++ {
+			s := PaiGowSetting{low: [2]Card{cards[i], cards[j]}}
+			n := 0
+			for k, c := range cards {
+				if k != i && k != j {
+					s.high[n] = c
+					n++
+				}
+			}
+			settings = append(settings, s)
+		}
+	}
+
+	return settings
+}
+
+// paiGowResult settles a player's setting against the dealer's: 1 if the
+// player wins both hands, -1 if the player wins neither and 0 for a push.
+// Copies go to the dealer, and a fouled setting loses.
+func paiGowResult(player, dealer PaiGowSetting) int {
+	if player.fouled() {
+		return -1
+	}
+	high := paiGowHigh(player.high) > paiGowHigh(dealer.high)
+	low := paiGowLow(player.low) > paiGowLow(dealer.low)
+	switch {
+	case high && low:
+		return 1
+	case !high && !low:
+		return -1
+	}
+
+	return 0
+}
+
+// paiGowGroups sorts cards into groups of equal rank, the joker counting as
+// an ace, largest group first and then by rank.
+func paiGowGroups(cards [7]Card) [][]Card {
+	byRank := make(map[Rank][]Card)
+	for _, c := range cards {
+		r := c.rank
+		if c == joker {
+			r = Ace
+		}
+		byRank[r] = append(byRank[r], c)
+	}
+	groups := make([][]Card, 0, len(byRank))
+	for _, g := range byRank {
+		groups = append(groups, g)
+	}
+	rank := func(g []Card) Rank {
+		if g[0] == joker {
+			return Ace
+		}
+		return g[0].rank
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return rank(groups[i]) > rank(groups[j])
+	})
+
+	return groups
+}
+
+// setLow builds the setting that plays the given cards low.
+func setLow(cards [7]Card, low ...Card) PaiGowSetting {
+	for _, s := range paiGowSettings(cards) {
+		if (s.low[0] == low[0] && s.low[1] == low[1]) || (s.low[0] == low[1] && s.low[1] == low[0]) {
+			return s
+		}
+	}
+	return PaiGowSetting{}
+}
+
+// bestLowWith returns the non-fouling setting with the strongest low hand
+// among those whose high hand is at least the given category.
+func bestLowWith(cards [7]Card, min HandRank) (PaiGowSetting, bool) {
+	var best PaiGowSetting
+	var low handValue
+	found := false
+	for _, s := range paiGowSettings(cards) {
+		if s.fouled() || paiGowHigh(s.high).handRank() < min {
+			continue
+		}
+		if v := paiGowLow(s.low); !found || v > low {
+			best, low, found = s, v, true
+		}
+	}
+
+	return best, found
+}
+
+// houseWay sets a hand the way the house sets the dealer's:
+//   - no pair or one pair: a straight or flush plays high if there is one,
+//     otherwise the next two cards below the highest card and the pair go low
+//   - two pair: split, unless both pairs are sixes or lower and there is an
+//     ace to play low
+//   - three pair: the highest pair goes low
+//   - trips: aces split into a pair high and an ace low, other trips stay high
+//   - full house or two trips: the pair, or a pair from the higher trips,
+//     goes low
+//   - quads: sixes or lower stay together, higher quads split unless an ace
+//     can go low with quads below jacks
+//
+// A fouled pick falls back to the non-fouling setting with the strongest low.
+func houseWay(cards [7]Card) PaiGowSetting {
+	groups := paiGowGroups(cards)
+	var pairs, singles [][]Card
+	var trips, quads [][]Card
+	for _, g := range groups {
+		switch len(g) {
+		case 1:
+			singles = append(singles, g)
+		case 2:
+			pairs = append(pairs, g)
+		case 3:
+			trips = append(trips, g)
+		default:
+			quads = append(quads, g)
+		}
+	}
+	rank := func(g []Card) Rank {
+		if g[0] == joker {
+			return Ace
+		}
+		return g[0].rank
+	}
+	hasAce := len(singles) > 0 && rank(singles[0]) == Ace
+
+	var s PaiGowSetting
+	switch {
+	case len(quads) > 0:
+		q := quads[0]
+		switch {
+		case len(q) == 5 && len(pairs) > 0:
+			s = setLow(cards, pairs[0]...)
+		case len(q) == 5:
+			s = setLow(cards, q[3], q[4])
+		case len(trips) > 0:
+			s = setLow(cards, trips[0][0], trips[0][1])
+		case rank(q) <= Six || (rank(q) < Jack && hasAce):
+			if len(pairs) > 0 {
+				s = setLow(cards, pairs[0]...)
+			} else {
+				s = setLow(cards, groups[1][0], groups[2][0])
+			}
+		default:
+			s = setLow(cards, q[2], q[3])
+		}
+	case len(trips) > 1:
+		s = setLow(cards, trips[0][0], trips[0][1])
+	case len(trips) == 1 && len(pairs) > 0:
+		s = setLow(cards, pairs[0]...)
+	case len(trips) == 1 && rank(trips[0]) == Ace:
+		s = setLow(cards, trips[0][2], singles[0][0])
+	case len(trips) == 1:
+		if st, ok := bestLowWith(cards, Straight); ok {
+			return st
+		}
+		s = setLow(cards, singles[0][0], singles[1][0])
+	case len(pairs) == 3:
+		s = setLow(cards, pairs[0]...)
+	case len(pairs) == 2:
+		if rank(pairs[0]) <= Six && hasAce {
+			s = setLow(cards, singles[0][0], singles[1][0])
+		} else {
+			s = setLow(cards, pairs[1]...)
+		}
+	default:
+		if st, ok := bestLowWith(cards, Straight); ok {
+			return st
+		}
+		if len(pairs) == 1 {
+			s = setLow(cards, singles[0][0], singles[1][0])
+		} else {
+			s = setLow(cards, singles[1][0], singles[2][0])
+		}
+	}
+	if s.fouled() {
+		s, _ = bestLowWith(cards, HighCard)
+	}
+
+	return s
+}
+
+// optimalSetting tries every non-fouling setting against the dealer hands,
+// each set the house way, and returns the one with the best average result.
+func optimalSetting(cards [7]Card, dealers [][7]Card) (PaiGowSetting, float64) {
+	dealt := make([]PaiGowSetting, len(dealers))
+	for i, d := range dealers {
+		dealt[i] = houseWay(d)
+	}
+
+	var best PaiGowSetting
+	max := -2.0
+	for _, s := range paiGowSettings(cards) {
+		if s.fouled() {
+			continue
+		}
+		total := 0
+		for _, d := range dealt {
+			total += paiGowResult(s, d)
+		}
+		if ev := float64(total) / float64(len(dealt)); ev > max {
+			best, max = s, ev
+		}
+	}
+
+	return best, max
+}
+
+// sampleDealers deals n dealer hands from the cards the player does not
+// hold.
+func sampleDealers(cards [7]Card, n int) [][7]Card {
+	held := make(map[Card]bool)
+	for _, c := range cards {
+		held[c] = true
+	}
+	rest := make([]Card, 0, 46)
+	for _, c := range paiGowDeck() {
+		if !held[c] {
+			rest = append(rest, c)
+		}
+	}
+
+	dealers := make([][7]Card, n)
+	for i := range dealers {
+		shuffle(rest)
+		dealers[i] = [7]Card(rest[:7])
+	}
+
+	return dealers
+}
@@ -0,0 +1,246 @@
+package poker
+
+import (
+	"testing"
+)
+
+func Test_paiGowHigh(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     [5]Card
+		wantScore HandRank
+		wantRanks [5]Rank
+	}{
+		{
+			name:      "joker completes a straight",
+			cards:     [5]Card{{Nine, Spade}, {Eight, Heart}, joker, {Six, Club}, {Five, Club}},
+			wantScore: Straight,
+			wantRanks: [5]Rank{Nine, Eight, Seven, Six, Five},
+		},
+		{
+			name:      "joker completes a flush as an ace",
+			cards:     [5]Card{{Nine, Heart}, {Two, Heart}, joker, {Six, Heart}, {Jack, Heart}},
+			wantScore: Flush,
+			wantRanks: [5]Rank{Ace, Jack, Nine, Six, Two},
+		},
+		{
+			name:      "joker pairs an ace",
+			cards:     [5]Card{{Ace, Spade}, {Eight, Heart}, joker, {Six, Club}, {Two, Club}},
+			wantScore: Pair,
+			wantRanks: [5]Rank{Ace, Eight, Six, Two},
+		},
+		{
+			name:      "joker is an ace, not a wild card",
+			cards:     [5]Card{{King, Spade}, {King, Heart}, joker, {Six, Club}, {Two, Club}},
+			wantScore: Pair,
+			wantRanks: [5]Rank{King, Ace, Six, Two},
+		},
+		{
+			name:      "five aces",
+			cards:     [5]Card{{Ace, Spade}, {Ace, Heart}, joker, {Ace, Club}, {Ace, Diamond}},
+			wantScore: fiveAces,
+			wantRanks: [5]Rank{Ace},
+		},
+		{
+			name:      "wheel is the second highest straight",
+			cards:     [5]Card{{Ace, Spade}, {Two, Heart}, {Three, Diamond}, {Four, Club}, {Five, Club}},
+			wantScore: Straight,
+			wantRanks: paiGowWheel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := paiGowHigh(tt.cards)
+			if got := v.handRank(); got != tt.wantScore {
+				t.Errorf("paiGowHigh() = %v, want %v", got, tt.wantScore)
+			}
+			if got := v.ranks(); got != tt.wantRanks {
+				t.Errorf("paiGowHigh() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+
+	wheel := paiGowHigh([5]Card{{Ace, Spade}, {Two, Heart}, {Three, Diamond}, {Four, Club}, {Five, Club}})
+	broadway := paiGowHigh([5]Card{{Ace, Spade}, {King, Heart}, {Queen, Diamond}, {Jack, Club}, {Ten, Club}})
+	king := paiGowHigh([5]Card{{Nine, Spade}, {King, Heart}, {Queen, Diamond}, {Jack, Club}, {Ten, Club}})
+	if !(king < wheel && wheel < broadway) {
+		t.Errorf("wheel does not rank between king high and ace high straights")
+	}
+}
+
+func TestPaiGowSetting_fouled(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting PaiGowSetting
+		want    bool
+	}{
+		{
+			name: "pair low over high card",
+			setting: PaiGowSetting{
+				high: [5]Card{{Ace, Spade}, {King, Heart}, {Nine, Diamond}, {Four, Club}, {Two, Club}},
+				low:  [2]Card{{Three, Spade}, {Three, Heart}},
+			},
+			want: true,
+		},
+		{
+			name: "joker low is an ace",
+			setting: PaiGowSetting{
+				high: [5]Card{{King, Spade}, {King, Heart}, {Nine, Diamond}, {Four, Club}, {Two, Club}},
+				low:  [2]Card{joker, {Three, Heart}},
+			},
+			want: false,
+		},
+		{
+			name: "same pair with kickers high",
+			setting: PaiGowSetting{
+				high: [5]Card{{Nine, Spade}, {Nine, Heart}, {Eight, Diamond}, {Four, Club}, {Two, Club}},
+				low:  [2]Card{{Nine, Club}, {Nine, Diamond}},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.setting.fouled(); got != tt.want {
+				t.Errorf("fouled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_paiGowResult(t *testing.T) {
+	player := PaiGowSetting{
+		high: [5]Card{{King, Spade}, {King, Heart}, {Nine, Diamond}, {Four, Club}, {Two, Club}},
+		low:  [2]Card{{Queen, Spade}, {Jack, Heart}},
+	}
+	tests := []struct {
+		name   string
+		dealer PaiGowSetting
+		want   int
+	}{
+		{
+			name: "wins both",
+			dealer: PaiGowSetting{
+				high: [5]Card{{Queen, Club}, {Queen, Heart}, {Nine, Spade}, {Four, Spade}, {Two, Spade}},
+				low:  [2]Card{{Ten, Spade}, {Eight, Heart}},
+			},
+			want: 1,
+		},
+		{
+			name: "copy goes to the dealer",
+			dealer: PaiGowSetting{
+				high: [5]Card{{Queen, Club}, {Queen, Heart}, {Nine, Spade}, {Four, Spade}, {Two, Spade}},
+				low:  [2]Card{{Queen, Diamond}, {Jack, Club}},
+			},
+			want: 0,
+		},
+		{
+			name: "loses both",
+			dealer: PaiGowSetting{
+				high: [5]Card{{Ace, Club}, {Ace, Heart}, {Nine, Spade}, {Four, Spade}, {Two, Spade}},
+				low:  [2]Card{{Ace, Diamond}, {Three, Club}},
+			},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paiGowResult(player, tt.dealer); got != tt.want {
+				t.Errorf("paiGowResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_houseWay(t *testing.T) {
+	tests := []struct {
+		name    string
+		cards   [7]Card
+		wantLow [2]Rank
+	}{
+		{
+			name:    "no pair keeps the highest card high",
+			cards:   [7]Card{{Ace, Spade}, {King, Heart}, {Nine, Diamond}, {Seven, Club}, {Five, Club}, {Three, Spade}, {Two, Heart}},
+			wantLow: [2]Rank{King, Nine},
+		},
+		{
+			name:    "one pair",
+			cards:   [7]Card{{Ace, Spade}, {King, Heart}, {Nine, Diamond}, {Nine, Club}, {Five, Club}, {Three, Spade}, {Two, Heart}},
+			wantLow: [2]Rank{Ace, King},
+		},
+		{
+			name:    "two pair split",
+			cards:   [7]Card{{Ace, Spade}, {King, Heart}, {King, Diamond}, {Nine, Club}, {Nine, Spade}, {Three, Spade}, {Two, Heart}},
+			wantLow: [2]Rank{Nine, Nine},
+		},
+		{
+			name:    "small two pair with an ace",
+			cards:   [7]Card{{Ace, Spade}, {Six, Heart}, {Six, Diamond}, {Four, Club}, {Four, Spade}, {Three, Spade}, {Jack, Heart}},
+			wantLow: [2]Rank{Ace, Jack},
+		},
+		{
+			name:    "full house",
+			cards:   [7]Card{{Ace, Spade}, {Six, Heart}, {Six, Diamond}, {Six, Club}, {Four, Spade}, {Four, Club}, {Jack, Heart}},
+			wantLow: [2]Rank{Four, Four},
+		},
+		{
+			name:    "trip aces split",
+			cards:   [7]Card{{Ace, Spade}, {Ace, Heart}, joker, {Six, Club}, {Four, Spade}, {Three, Club}, {Jack, Heart}},
+			wantLow: [2]Rank{Ace, Jack},
+		},
+		{
+			name:    "straight plays high",
+			cards:   [7]Card{{Ten, Spade}, {Nine, Heart}, {Eight, Diamond}, {Seven, Club}, {Six, Spade}, {King, Club}, {Queen, Heart}},
+			wantLow: [2]Rank{King, Queen},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := houseWay(tt.cards)
+			if s.fouled() {
+				t.Fatalf("houseWay() = %v, fouled", s)
+			}
+			low := paiGowLow(s.low)
+			want := paiGowLow([2]Card{{rank: tt.wantLow[0]}, {rank: tt.wantLow[1], suit: Heart}})
+			if low != want {
+				t.Errorf("houseWay() low = %v, want %v", s.low, tt.wantLow)
+			}
+		})
+	}
+}
+
+func Test_optimalSetting(t *testing.T) {
+	hands, err := paiGowDeal(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dealers := sampleDealers(hands[0], 200)
+	best, ev := optimalSetting(hands[0], dealers)
+	if best.fouled() {
+		t.Errorf("optimalSetting() = %v, fouled", best)
+	}
+
+	house := houseWay(hands[0])
+	total := 0
+	for _, d := range dealers {
+		total += paiGowResult(house, houseWay(d))
+	}
+	if hw := float64(total) / float64(len(dealers)); hw > ev {
+		t.Errorf("optimalSetting() = %v, worse than the house way %v", ev, hw)
+	}
+}
+
+func Test_paiGowDeal(t *testing.T) {
+	if _, err := paiGowDeal(7); err != nil {
+		t.Errorf("paiGowDeal() error = %v", err)
+	}
+	if _, err := paiGowDeal(8); err == nil {
+		t.Errorf("paiGowDeal() error = nil, want not enough cards")
+	}
+	for i := 0; i < 1000; i++ {
+		hands, _ := paiGowDeal(1)
+		if s := houseWay(hands[0]); s.fouled() {
+			t.Fatalf("houseWay(%v) = %v, fouled", hands[0], s)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package poker
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"unicode"
+)
+
+// glyphs is a small bitmap font for rasterizing scenes without a font
+// package: digits, capital letters, the suits and the joker's star, seven
+// pixels high. Lowercase letters are drawn as capitals and anything else as
+// a space.
+var glyphs = map[rune][7]string{
+	' ': {"   ", "   ", "   ", "   ", "   ", "   ", "   "},
+	'0': {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'2': {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+	'3': {"#####", "   # ", "  #  ", "   # ", "    #", "#   #", " ### "},
+	'4': {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+	'5': {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+	'6': {"  ## ", " #   ", "#    ", "#### ", "#   #", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", " #   ", " #   ", " #   "},
+	'8': {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+	'9': {" ### ", "#   #", "#   #", " ####", "    #", "   # ", " ##  "},
+	'A': {" ### ", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#   #", "#### ", "#   #", "#   #", "#### "},
+	'C': {" ### ", "#   #", "#    ", "#    ", "#    ", "#   #", " ### "},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#    "},
+	'G': {" ### ", "#   #", "#    ", "# ###", "#   #", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'I': {" ### ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'J': {"  ###", "   # ", "   # ", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "# #  ", "##   ", "# #  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "# # #", "#   #", "#   #", "#   #"},
+	'N': {"#   #", "#   #", "##  #", "# # #", "#  ##", "#   #", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#   #", "#### ", "#    ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "#   #", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#   #", "#### ", "# #  ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", "#    ", " ### ", "    #", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "#   #", "# # #", "# # #", "# # #", " # # "},
+	'X': {"#   #", "#   #", " # # ", "  #  ", " # # ", "#   #", "#   #"},
+	'Y': {"#   #", "#   #", " # # ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "    #", "   # ", "  #  ", " #   ", "#    ", "#####"},
+	'♠': {"   #   ", "  ###  ", " ##### ", "#######", "#######", "   #   ", "  ###  "},
+	'♦': {"   #   ", "  ###  ", " ##### ", "#######", " ##### ", "  ###  ", "   #   "},
+	'♣': {"  ###  ", "  ###  ", "## # ##", "#######", "## # ##", "   #   ", "  ###  "},
+	'♥': {" ## ## ", "#######", "#######", "#######", " ##### ", "  ###  ", "   #   "},
+	'★': {"   #   ", "   #   ", "#######", " ##### ", "  ###  ", " ## ## ", "##   ##"},
+}
+
+func glyph(r rune) [7]string {
+	if g, ok := glyphs[unicode.ToUpper(r)]; ok {
+		return g
+	}
+	return glyphs[' ']
+}
+
+// textWidth returns how wide text is drawn at the given scale, with a pixel
+// of space after each letter.
+func textWidth(text string, scale int) int {
+	w := 0
+	for _, r := range text {
+		w += (len(glyph(r)[0]) + 1) * scale
+	}
+	return w - scale
+}
+
+func drawText(img *image.RGBA, sh shape) {
+	scale := sh.size / 7
+	if scale < 1 {
+		scale = 1
+	}
+	x, y := sh.x, sh.y-7*scale/2
+	if sh.middle {
+		x -= textWidth(sh.text, scale) / 2
+	}
+	for _, r := range sh.text {
+		g := glyph(r)
+		for row, line := range g {
+			for col, c := range line {
+				if c != '#' {
+					continue
+				}
+				px := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(img, px, image.NewUniform(sh.fill), image.Point{}, draw.Over)
+			}
+		}
+		x += (len(g[0]) + 1) * scale
+	}
+}
+
+// fillRoundRect fills a rectangle leaving out what lies beyond its rounded
+// corners.
+func fillRoundRect(img *image.RGBA, r image.Rectangle, radius int, c color.RGBA) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			dx, dy := 0, 0
+			switch {
+			case x < r.Min.X+radius:
+				dx = r.Min.X + radius - x
+			case x >= r.Max.X-radius:
+				dx = x - (r.Max.X - radius - 1)
+			}
+			switch {
+			case y < r.Min.Y+radius:
+				dy = r.Min.Y + radius - y
+			case y >= r.Max.Y-radius:
+				dy = y - (r.Max.Y - radius - 1)
+			}
+			if dx*dx+dy*dy <= radius*radius {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+func fillEllipse(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	cx, cy := float64(r.Min.X+r.Max.X)/2, float64(r.Min.Y+r.Max.Y)/2
+	rx, ry := float64(r.Dx())/2, float64(r.Dy())/2
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			dx, dy := (float64(x)+0.5-cx)/rx, (float64(y)+0.5-cy)/ry
+			if dx*dx+dy*dy <= 1 {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// raster draws the scene. Outlines are a pixel wide: each shape is filled
+// in its stroke color and then again in its fill color a pixel inside.
+func (s *scene) raster() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, s.width, s.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(s.background), image.Point{}, draw.Src)
+	for _, sh := range s.shapes {
+		r := image.Rect(sh.x, sh.y, sh.x+sh.w, sh.y+sh.h)
+		switch sh.kind {
+		case rectShape:
+			fillRoundRect(img, r, sh.radius, sh.stroke)
+			fillRoundRect(img, r.Inset(1), sh.radius-1, sh.fill)
+		case ellipseShape:
+			fillEllipse(img, r, sh.stroke)
+			fillEllipse(img, r.Inset(1), sh.fill)
+		case textShape:
+			drawText(img, sh)
+		}
+	}
+
+	return img
+}
+
+// png writes the scene as a PNG image.
+func (s *scene) png(w io.Writer) error {
+	return png.Encode(w, s.raster())
+}
+
+// cardsPNG draws a hand or a board as a PNG image.
+func cardsPNG(w io.Writer, cards []Card, fourColor bool) error {
+	return cardsScene(cards, fourColor).png(w)
+}
+
+// png draws the table as a PNG image.
+func (snap *tableSnapshot) png(w io.Writer, fourColor bool) error {
+	return snap.scene(fourColor).png(w)
+}
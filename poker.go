@@ -14,16 +14,34 @@ func deck() []Card {
 	return cards
 }
 
+func shuffle(cards []Card) {
+	shuffleWith(nil, cards)
+}
+
+// shuffleWith shuffles the cards with r, or with the global source when r is
+// nil, so a seeded source replays the same deal.
+func shuffleWith(r *rand.Rand, cards []Card) {
+	for i := range cards {
+		var j int
+		if r == nil {
+			j = rand.Intn(i + 1)
+		} else {
+			j = r.Intn(i + 1)
+		}
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
 func deal(numHands int) ([]Hand, error) {
+	return dealWith(nil, numHands)
+}
+
+func dealWith(r *rand.Rand, numHands int) ([]Hand, error) {
 	cards := deck()
 	if 5*numHands > len(cards) {
 		return nil, fmt.Errorf("not enough cards in the deck")
 	}
-	// shuffle the deck
-	for i := range cards {
-		j := rand.Intn(i + 1)
-		cards[i], cards[j] = cards[j], cards[i]
-	}
+	shuffleWith(r, cards)
 
 	hands := make([]Hand, numHands)
 	for i := range hands {
@@ -51,20 +69,12 @@ func play(hands []Hand) []Hand {
 			continue
 		}
 		if h.handRank == max.handRank {
-			var idx int
-			for i, r := range h.ranks {
-				if r > max.ranks[i] {
-					winners = winners[:0]
-					winners = append(winners, h)
-					max = h
-					break
-				}
-				if r < max.ranks[i] {
-					break
-				}
-				idx++
-			}
-			if idx == 5 {
+			switch c := compare(&h, &max); {
+			case c > 0:
+				winners = winners[:0]
+				winners = append(winners, h)
+				max = h
+			case c == 0:
 				winners = append(winners, h)
 			}
 		}
@@ -79,7 +89,7 @@ func poker(numHands int) error {
 		fmt.Println(err)
 		return err
 	}
-	
+
 	for _, h := range hands {
 		fmt.Printf("%s\n", h.String())
 	}
@@ -88,7 +98,7 @@ func poker(numHands int) error {
 	fmt.Printf("%d Winner(s):\n", len(winners))
 
 	for _, w := range winners {
-		fmt.Printf("%s: %s, %s\n", w.String(), w.handRank, w.ranks)
+		fmt.Printf("%s: %s\n", w.String(), w.describe())
 	}
 
 	return nil
@@ -0,0 +1,784 @@
+package poker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PokerStars hand histories are plain text: a header naming the game,
+// stakes and table, the seats, then each street's actions and a summary.
+// Hands follow one another, each starting with a line that begins
+// "PokerStars".
+
+var (
+	starsHeader    = regexp.MustCompile(`^PokerStars (?:Zoom )?(?:Hand|Game) #(\d+):\s+(.*) - (\d{4}/\d{2}/\d{2} .*)$`)
+	starsGame      = regexp.MustCompile(`^(?:Tournament #(\d+), (.+?) )?(Hold'em|Omaha|7 Card Stud) (No Limit|Pot Limit|Limit)(?: - Level ([IVXLCDM]+))? \((\S+)/(\S+?)(?: ([A-Z]{3}))?\)$`)
+	starsTable     = regexp.MustCompile(`^Table '([^']*)' (\d+)-max(?: \(Play Money\))?(?: Seat #(\d+) is the button)?$`)
+	starsSeat      = regexp.MustCompile(`^Seat (\d+): (.+) \((\S+) in chips[^)]*\)(.*)$`)
+	starsStreet    = regexp.MustCompile(`^\*\*\* (.+?) \*\*\*(.*)$`)
+	starsDealt     = regexp.MustCompile(`^Dealt to (.+?) ((?:\[[^\]]*\] ?)+)$`)
+	starsCards     = regexp.MustCompile(`\[([^\]]*)\]`)
+	starsUncalled  = regexp.MustCompile(`^Uncalled bet \((\S+)\) returned to (.+)$`)
+	starsCollected = regexp.MustCompile(`^(.+) collected (\S+) from (?:side |main )?pot`)
+	starsShows     = regexp.MustCompile(`^shows (\[[^\]]*\])(?: \((.*)\))?$`)
+)
+
+// starsGames maps the names PokerStars gives games to ours.
+var starsGames = map[string]string{"Hold'em": holdemGame, "Omaha": omahaGame, "7 Card Stud": studGame}
+
+var currencySymbols = map[string]string{"USD": "$", "EUR": "€", "GBP": "£"}
+
+// parseAmount reads "1,500" as chips, or "$2" and "€0.25" as cents.
+func parseAmount(s string, cash bool) (int, error) {
+	text := strings.ReplaceAll(strings.TrimLeft(s, "$€£"), ",", "")
+	whole, frac, dot := strings.Cut(text, ".")
+	n, err := strconv.Atoi(whole)
+	if err != nil || (dot && !cash) || len(frac) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if !cash {
+		return n, nil
+	}
+	cents := 0
+	if frac != "" {
+		if cents, err = strconv.Atoi((frac + "0")[:2]); err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	return n*100 + cents, nil
+}
+
+// formatAmount writes chips, or cents with the currency's symbol, leaving off
+// whole cents the way PokerStars does.
+func formatAmount(n int, currency string) string {
+	switch {
+	case currency == "":
+		return strconv.Itoa(n)
+	case n%100 == 0:
+		return currencySymbols[currency] + strconv.Itoa(n/100)
+	}
+	return fmt.Sprintf("%s%d.%02d", currencySymbols[currency], n/100, n%100)
+}
+
+// cardsIn returns the cards in each bracketed group of a line.
+func cardsIn(s string) ([][]Card, error) {
+	groups := make([][]Card, 0)
+	for _, m := range starsCards.FindAllStringSubmatch(s, -1) {
+		cards, err := parseCards(m[1])
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, cards)
+	}
+	return groups, nil
+}
+
+// readPokerStars reads every hand in a PokerStars hand history file.
+func readPokerStars(r io.Reader) ([]*HandHistory, error) {
+	histories := make([]*HandHistory, 0)
+	var lines []string
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		hh, err := parseStarsHand(lines)
+		if err != nil {
+			return err
+		}
+		histories = append(histories, hh)
+		lines = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\uFEFF"), "\r ")
+		switch {
+		case strings.HasPrefix(line, "PokerStars "):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			lines = append(lines, line)
+		case line != "" && len(lines) > 0:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// starsParser reads one hand a line at a time.
+type starsParser struct {
+	hh     *HandHistory
+	cash   bool
+	street Street
+	// bets are the live chips each seat has in front of it this street.
+	bets    map[int]int
+	dealt   map[string]bool
+	summary bool
+}
+
+func parseStarsHand(lines []string) (*HandHistory, error) {
+	m := starsHeader.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil, fmt.Errorf("not a PokerStars hand: %q", lines[0])
+	}
+	number, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid hand number %q", m[1])
+	}
+	g := starsGame.FindStringSubmatch(m[2])
+	if g == nil {
+		return nil, fmt.Errorf("hand %d: unsupported game %q", number, m[2])
+	}
+
+	p := &starsParser{
+		hh:    &HandHistory{Game: starsGames[g[3]], Limit: g[4], Number: number, Time: m[3], Currency: g[8], Button: -1},
+		cash:  g[6][0] < '0' || g[6][0] > '9',
+		bets:  make(map[int]int),
+		dealt: make(map[string]bool),
+	}
+	if g[1] != "" {
+		id, err := strconv.ParseInt(g[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("hand %d: invalid tournament %q", number, g[1])
+		}
+		p.hh.Tournament = &TournamentRecord{ID: id, BuyIn: g[2], Level: g[5]}
+	}
+	if p.hh.Small, err = parseAmount(g[6], p.cash); err != nil {
+		return nil, fmt.Errorf("hand %d: %w", number, err)
+	}
+	if p.hh.Big, err = parseAmount(g[7], p.cash); err != nil {
+		return nil, fmt.Errorf("hand %d: %w", number, err)
+	}
+	if p.hh.Game == studGame {
+		p.street = ThirdStreet
+	}
+
+	for _, line := range lines[1:] {
+		if err := p.line(line); err != nil {
+			return nil, fmt.Errorf("hand %d: %w", number, err)
+		}
+	}
+	p.finish()
+
+	return p.hh, nil
+}
+
+func (p *starsParser) line(line string) error {
+	if p.summary {
+		return nil
+	}
+	hh := p.hh
+	if m := starsTable.FindStringSubmatch(line); m != nil {
+		hh.Table = m[1]
+		hh.TableSize, _ = strconv.Atoi(m[2])
+		if m[3] != "" {
+			button, _ := strconv.Atoi(m[3])
+			hh.Button = button - 1
+		}
+		return nil
+	}
+	if m := starsSeat.FindStringSubmatch(line); m != nil && len(p.dealt) == 0 && len(hh.Actions) == 0 {
+		if strings.Contains(m[4], "sitting out") || strings.Contains(m[4], "out of hand") {
+			return nil
+		}
+		seat, _ := strconv.Atoi(m[1])
+		stack, err := parseAmount(m[3], p.cash)
+		if err != nil {
+			return err
+		}
+		hh.Seats = append(hh.Seats, SeatRecord{Seat: seat - 1, Name: m[2], Stack: stack})
+		return nil
+	}
+	if m := starsStreet.FindStringSubmatch(line); m != nil {
+		return p.newStreet(m[1], m[2])
+	}
+	if m := starsDealt.FindStringSubmatch(line); m != nil {
+		return p.deal(m[1], m[2])
+	}
+	if m := starsUncalled.FindStringSubmatch(line); m != nil {
+		return p.award(m[2], m[1])
+	}
+	if m := starsCollected.FindStringSubmatch(line); m != nil && p.seatOf(m[1]) != nil {
+		return p.award(m[1], m[2])
+	}
+	if seat, rest := p.actor(line); seat != nil {
+		return p.action(seat, rest)
+	}
+	// chat, arrivals and timeouts
+	return nil
+}
+
+// seatOf finds a player by name.
+func (p *starsParser) seatOf(name string) *SeatRecord {
+	for i := range p.hh.Seats {
+		if p.hh.Seats[i].Name == name {
+			return &p.hh.Seats[i]
+		}
+	}
+	return nil
+}
+
+// actor finds the player a "Name: action" line is about, trying longer names
+// first since names may contain colons and spaces.
+func (p *starsParser) actor(line string) (*SeatRecord, string) {
+	var found *SeatRecord
+	for i := range p.hh.Seats {
+		s := &p.hh.Seats[i]
+		if strings.HasPrefix(line, s.Name+": ") && (found == nil || len(s.Name) > len(found.Name)) {
+			found = s
+		}
+	}
+	if found == nil {
+		return nil, ""
+	}
+	return found, line[len(found.Name)+2:]
+}
+
+func (p *starsParser) newStreet(name, rest string) error {
+	stud := p.hh.Game == studGame
+	streets := map[string]Street{
+		"FLOP": Flop, "TURN": Turn, "RIVER": River,
+		"4th STREET": FourthStreet, "5th STREET": FifthStreet, "6th STREET": SixthStreet,
+	}
+	switch name {
+	case "HOLE CARDS", "3rd STREET", "SHOW DOWN":
+		return nil
+	case "SUMMARY":
+		p.summary = true
+		return nil
+	}
+	street, ok := streets[name]
+	if !ok {
+		return fmt.Errorf("unsupported street %q", name)
+	}
+	if stud && street == River {
+		street = SeventhStreet
+	}
+	p.street = street
+	p.bets = make(map[int]int)
+	if !stud {
+		groups, err := cardsIn(rest)
+		if err != nil {
+			return err
+		}
+		p.hh.Board = nil
+		for _, g := range groups {
+			p.hh.Board = append(p.hh.Board, g...)
+		}
+	}
+	return nil
+}
+
+// deal reads a "Dealt to" line. Hold'em and Omaha only deal to the hero.
+// Stud deals to everyone: the hero's lines show every card and the others'
+// only what is face up, the new cards last.
+func (p *starsParser) deal(name, text string) error {
+	seat := p.seatOf(name)
+	if seat == nil {
+		return fmt.Errorf("cards dealt to unknown player %q", name)
+	}
+	groups, err := cardsIn(text)
+	if err != nil {
+		return err
+	}
+	p.dealt[name] = true
+	if p.hh.Game != studGame {
+		seat.Cards = nil
+		for _, g := range groups {
+			seat.Cards = append(seat.Cards, g...)
+		}
+		return nil
+	}
+	dealt := groups[len(groups)-1]
+	if p.street == ThirdStreet && len(dealt) == 3 {
+		p.hh.Hero = name
+	}
+	if p.hh.Hero == name {
+		seat.Cards = append(seat.Cards, dealt...)
+	} else {
+		seat.Up = append(seat.Up, dealt...)
+	}
+	return nil
+}
+
+func (p *starsParser) award(name, amount string) error {
+	seat := p.seatOf(name)
+	if seat == nil {
+		return fmt.Errorf("award to unknown player %q", name)
+	}
+	n, err := parseAmount(amount, p.cash)
+	if err != nil {
+		return err
+	}
+	// a player who collects from several pots, or takes back an uncalled
+	// bet as well, gets one award for all of it as the engine records it
+	for i := range p.hh.Awards {
+		if p.hh.Awards[i].Seat == seat.Seat {
+			p.hh.Awards[i].Amount += n
+			return nil
+		}
+	}
+	p.hh.Awards = append(p.hh.Awards, Award{Seat: seat.Seat, Amount: n})
+	return nil
+}
+
+func (p *starsParser) action(seat *SeatRecord, rest string) error {
+	a := Action{Street: p.street, Seat: seat.Seat}
+	if text, ok := strings.CutSuffix(rest, " and is all-in"); ok {
+		rest, a.AllIn = text, true
+	}
+	level := 0
+	for _, b := range p.bets {
+		if b > level {
+			level = b
+		}
+	}
+
+	var amount string
+	cut := func(prefix string) bool {
+		text, ok := strings.CutPrefix(rest, prefix)
+		if ok {
+			amount = text
+		}
+		return ok
+	}
+	switch {
+	case cut("posts small & big blinds "):
+		// the big blind is live and the small blind dead
+		n, err := parseAmount(amount, p.cash)
+		if err != nil {
+			return err
+		}
+		dead := p.hh.Small
+		if dead > n {
+			dead = n
+		}
+		p.add(Action{Street: p.street, Seat: seat.Seat, Kind: PostBigBlind, Amount: n - dead})
+		p.add(Action{Street: p.street, Seat: seat.Seat, Kind: PostDead, Amount: dead, AllIn: a.AllIn})
+		return nil
+	case cut("posts small blind "):
+		a.Kind = PostSmallBlind
+	case cut("posts big blind "):
+		a.Kind = PostBigBlind
+	case cut("posts the ante "):
+		a.Kind = PostAnte
+	case cut("brings in for "):
+		a.Kind = PostBringIn
+	case rest == "folds" || strings.HasPrefix(rest, "folds "):
+		a.Kind = Fold
+	case rest == "checks":
+		a.Kind = Check
+	case cut("calls "):
+		a.Kind = Call
+	case cut("bets "):
+		a.Kind = Bet
+	case cut("raises "), cut("completes it to "):
+		a.Kind = Raise
+		if _, to, ok := strings.Cut(amount, " to "); ok {
+			amount = to
+		}
+	case cut("shows "):
+		return p.shows(seat, rest)
+	default:
+		// mucks, doesn't show, sits out
+		return nil
+	}
+
+	if amount != "" {
+		n, err := parseAmount(amount, p.cash)
+		if err != nil {
+			return err
+		}
+		a.Amount = n
+		if a.Kind == Bet || a.Kind == Raise {
+			a.To = n
+			a.Amount = n - p.bets[seat.Seat]
+		}
+	}
+	if a.Kind == PostAnte && p.hh.Ante == 0 {
+		p.hh.Ante = a.Amount
+	}
+	p.add(a)
+	return nil
+}
+
+// add records an action and the live chips it put in front of the seat.
+func (p *starsParser) add(a Action) {
+	if a.Kind != PostAnte && a.Kind != PostDead {
+		p.bets[a.Seat] += a.Amount
+	}
+	p.hh.Actions = append(p.hh.Actions, a)
+}
+
+func (p *starsParser) shows(seat *SeatRecord, rest string) error {
+	m := starsShows.FindStringSubmatch(rest)
+	if m == nil {
+		return fmt.Errorf("invalid show %q", rest)
+	}
+	groups, err := cardsIn(m[1])
+	if err != nil {
+		return err
+	}
+	seat.Cards = groups[0]
+	p.hh.Showdown = append(p.hh.Showdown, ShowdownRecord{Seat: seat.Seat, Description: m[2]})
+	return nil
+}
+
+// finish names the hero and works out the category of every hand shown
+// whose cards and board are complete.
+func (p *starsParser) finish() {
+	hh := p.hh
+	if hh.Game != studGame && len(p.dealt) == 1 {
+		for name := range p.dealt {
+			hh.Hero = name
+		}
+	}
+	for i, sd := range hh.Showdown {
+		if v, ok := bestValue(hh.Game, hh.seat(sd.Seat).Cards, hh.Board); ok {
+			hh.Showdown[i].Category = v.handRank()
+		}
+	}
+}
+
+// bestValue evaluates a player's best hand: any five of hold'em's hole cards
+// and board or of a stud hand, and exactly two hole cards with three from
+// the board in Omaha.
+func bestValue(game string, hole, board []Card) (handValue, bool) {
+	switch {
+	case game == holdemGame && len(hole) == 2 && len(board) == 5,
+		game == studGame && len(hole) >= 5:
+		return evaluate(append(append([]Card(nil), hole...), board...)), true
+	case game == omahaGame && len(hole) == 4 && len(board) == 5:
+		return omahaValue(hole, board), true
+	}
+	return 0, false
+}
+
+func omahaValue(hole, board []Card) handValue {
+	var best handValue
+	cards := make([]Card, 5)
+	for a := 0; a < len(hole); a++ {
+		for b := a + 1; b < len(hole); b++ {
+			for i := 0; i < len(board); i++ {
+				for j := i + 1; j < len(board); j++ {
+					for k := j + 1; k < len(board); k++ {
+						cards[0], cards[1] = hole[a], hole[b]
+						cards[2], cards[3], cards[4] = board[i], board[j], board[k]
+						if v := evaluate(cards); v > best {
+							best = v
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// starsStreetNames are the headers that open each street after the first.
+var starsStreetNames = map[Street]string{
+	Flop: "FLOP", Turn: "TURN", River: "RIVER",
+	FourthStreet: "4th STREET", FifthStreet: "5th STREET", SixthStreet: "6th STREET", SeventhStreet: "RIVER",
+}
+
+// starsSummaryStreets name the streets in the summary.
+var starsSummaryStreets = map[Street]string{
+	Flop: "Flop", Turn: "Turn", River: "River",
+	ThirdStreet: "3rd Street", FourthStreet: "4th Street", FifthStreet: "5th Street", SixthStreet: "6th Street", SeventhStreet: "River",
+}
+
+// writePokerStars writes a hand in the PokerStars text format. Hands
+// without a time get a fixed one, and hands without a hero show everyone's
+// hole cards.
+func writePokerStars(w io.Writer, hh *HandHistory) error {
+	var game string
+	for name, g := range starsGames {
+		if g == hh.Game {
+			game = name
+		}
+	}
+	if game == "" {
+		return fmt.Errorf("hand %d: PokerStars has no %q games", hh.Number, hh.Game)
+	}
+	limit := hh.Limit
+	if limit == "" {
+		limit = "No Limit"
+	}
+	when := hh.Time
+	if when == "" {
+		when = "2000/01/01 00:00:00 ET"
+	}
+	table := hh.Table
+	if table == "" {
+		table = "Table 1"
+	}
+	amt := func(n int) string { return formatAmount(n, hh.Currency) }
+	names := make(map[int]string)
+	for _, s := range hh.Seats {
+		names[s.Seat] = s.Name
+	}
+	stud := hh.Game == studGame
+
+	b := &strings.Builder{}
+	stakes := amt(hh.Small) + "/" + amt(hh.Big)
+	if hh.Currency != "" {
+		stakes += " " + hh.Currency
+	}
+	if t := hh.Tournament; t != nil {
+		level := ""
+		if t.Level != "" {
+			level = " - Level " + t.Level
+		}
+		fmt.Fprintf(b, "PokerStars Hand #%d: Tournament #%d, %s %s %s%s (%s) - %s\n",
+			hh.Number, t.ID, t.BuyIn, game, limit, level, stakes, when)
+	} else {
+		fmt.Fprintf(b, "PokerStars Hand #%d:  %s %s (%s) - %s\n", hh.Number, game, limit, stakes, when)
+	}
+	if hh.Button >= 0 && !stud {
+		fmt.Fprintf(b, "Table '%s' %d-max Seat #%d is the button\n", table, hh.TableSize, hh.Button+1)
+	} else {
+		fmt.Fprintf(b, "Table '%s' %d-max\n", table, hh.TableSize)
+	}
+	seats := append([]SeatRecord(nil), hh.Seats...)
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+	for _, s := range seats {
+		fmt.Fprintf(b, "Seat %d: %s (%s in chips)\n", s.Seat+1, s.Name, amt(s.Stack))
+	}
+
+	first, last := hh.streets()
+	paid := make(map[int]int)
+	folded := make(map[int]Street)
+	level := 0
+	bets := make(map[int]int)
+	i := 0
+	for street := first; street <= last; street++ {
+		if street == first {
+			// antes and blinds go in before the cards are dealt, the
+			// bring-in after
+			for ; i < len(hh.Actions) && hh.Actions[i].Kind.post() && hh.Actions[i].Kind != PostBringIn; i++ {
+				a := hh.Actions[i]
+				paid[a.Seat] += a.Amount
+				if a.Kind != PostAnte && a.Kind != PostDead {
+					bets[a.Seat] += a.Amount
+				}
+				text := starsAction(a, amt, 0, false)
+				if next := i + 1; a.Kind == PostBigBlind && next < len(hh.Actions) &&
+					hh.Actions[next].Kind == PostDead && hh.Actions[next].Seat == a.Seat {
+					i++
+					paid[a.Seat] += hh.Actions[i].Amount
+					text = "posts small & big blinds " + amt(a.Amount+hh.Actions[i].Amount)
+				}
+				fmt.Fprintf(b, "%s: %s\n", names[a.Seat], text)
+			}
+			if stud {
+				b.WriteString("*** 3rd STREET ***\n")
+			} else {
+				b.WriteString("*** HOLE CARDS ***\n")
+			}
+		} else {
+			bets = make(map[int]int)
+			if stud {
+				fmt.Fprintf(b, "*** %s ***\n", starsStreetNames[street])
+			} else {
+				n := 3 + int(street-Flop)
+				if n == 3 {
+					fmt.Fprintf(b, "*** FLOP *** [%s]\n", formatCards(hh.Board[:3]))
+				} else {
+					fmt.Fprintf(b, "*** %s *** [%s] [%s]\n", starsStreetNames[street], formatCards(hh.Board[:n-1]), formatCards(hh.Board[n-1:n]))
+				}
+			}
+		}
+		for _, s := range hh.Seats {
+			if _, out := folded[s.Seat]; !out {
+				writeDealt(b, hh, &s, street, first)
+			}
+		}
+
+		for ; i < len(hh.Actions) && hh.Actions[i].Street == street; i++ {
+			a := hh.Actions[i]
+			level = 0
+			for _, v := range bets {
+				if v > level {
+					level = v
+				}
+			}
+			fmt.Fprintf(b, "%s: %s\n", names[a.Seat], starsAction(a, amt, level, stud && street == ThirdStreet && level < hh.Small))
+			paid[a.Seat] += a.Amount
+			if a.Kind != PostAnte && a.Kind != PostDead {
+				bets[a.Seat] += a.Amount
+			}
+			if a.Kind == Fold {
+				folded[a.Seat] = street
+			}
+		}
+	}
+
+	// the part of the largest bet nobody matched goes back
+	top, second := -1, 0
+	for _, s := range hh.Seats {
+		switch n := paid[s.Seat]; {
+		case top < 0 || n > paid[top]:
+			if top >= 0 {
+				second = paid[top]
+			}
+			top = s.Seat
+		case n > second:
+			second = n
+		}
+	}
+	uncalled := 0
+	if top >= 0 && paid[top] > second {
+		uncalled = paid[top] - second
+		fmt.Fprintf(b, "Uncalled bet (%s) returned to %s\n", amt(uncalled), names[top])
+	}
+
+	won := make(map[int]int)
+	for _, a := range hh.Awards {
+		won[a.Seat] += a.Amount
+	}
+	won[top] -= uncalled
+	shown := make(map[int]ShowdownRecord)
+	if len(hh.Showdown) > 0 {
+		b.WriteString("*** SHOW DOWN ***\n")
+		for _, sd := range hh.Showdown {
+			shown[sd.Seat] = sd
+			fmt.Fprintf(b, "%s: shows [%s] (%s)\n", names[sd.Seat], formatCards(hh.seat(sd.Seat).Cards), sd.Description)
+		}
+	}
+	total, collected := -uncalled, 0
+	for _, n := range paid {
+		total += n
+	}
+	for _, s := range hh.Seats {
+		if won[s.Seat] > 0 {
+			fmt.Fprintf(b, "%s collected %s from pot\n", s.Name, amt(won[s.Seat]))
+			collected += won[s.Seat]
+		}
+	}
+
+	b.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(b, "Total pot %s | Rake %s\n", amt(total), amt(total-collected))
+	if len(hh.Board) > 0 {
+		fmt.Fprintf(b, "Board [%s]\n", formatCards(hh.Board))
+	}
+	for _, s := range seats {
+		fmt.Fprintf(b, "Seat %d: %s%s %s\n", s.Seat+1, s.Name, starsPosition(hh, s.Seat), starsResult(hh, s, folded, shown, won, amt))
+	}
+	b.WriteString("\n\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// starsAction writes an action the way PokerStars does. level is the
+// largest bet before it; a stud raise of the bring-in completes the bet.
+func starsAction(a Action, amt func(int) string, level int, completes bool) string {
+	text := ""
+	switch a.Kind {
+	case PostAnte:
+		text = "posts the ante " + amt(a.Amount)
+	case PostSmallBlind, PostDead:
+		text = "posts small blind " + amt(a.Amount)
+	case PostBigBlind:
+		text = "posts big blind " + amt(a.Amount)
+	case PostBringIn:
+		text = "brings in for " + amt(a.Amount)
+	case Fold:
+		text = "folds"
+	case Check:
+		text = "checks"
+	case Call:
+		text = "calls " + amt(a.Amount)
+	case Bet:
+		text = "bets " + amt(a.To)
+	case Raise:
+		text = fmt.Sprintf("raises %s to %s", amt(a.To-level), amt(a.To))
+		if completes {
+			text = "completes it to " + amt(a.To)
+		}
+	}
+	if a.AllIn {
+		text += " and is all-in"
+	}
+	return text
+}
+
+// writeDealt writes the cards a seat is dealt on a street: everything for
+// hold'em and Omaha, and in stud the hero's cards or the others' face-up
+// cards, new ones last.
+func writeDealt(b *strings.Builder, hh *HandHistory, s *SeatRecord, street, first Street) {
+	if hh.Game != studGame {
+		if street == first && len(s.Cards) > 0 && (hh.Hero == "" || hh.Hero == s.Name) {
+			fmt.Fprintf(b, "Dealt to %s [%s]\n", s.Name, formatCards(s.Cards))
+		}
+		return
+	}
+	// cards dealt by the end of each stud street, and face up
+	n := 3 + int(street-ThirdStreet)
+	up := n - 2
+	if street == SeventhStreet {
+		up = 4
+	}
+	switch {
+	case (hh.Hero == "" || hh.Hero == s.Name) && len(s.Cards) >= n:
+		if street == ThirdStreet {
+			fmt.Fprintf(b, "Dealt to %s [%s]\n", s.Name, formatCards(s.Cards[:3]))
+		} else {
+			fmt.Fprintf(b, "Dealt to %s [%s] [%s]\n", s.Name, formatCards(s.Cards[:n-1]), formatCards(s.Cards[n-1:n]))
+		}
+	case street == SeventhStreet:
+	case len(s.Up) >= up && street == ThirdStreet:
+		fmt.Fprintf(b, "Dealt to %s [%s]\n", s.Name, formatCards(s.Up[:1]))
+	case len(s.Up) >= up:
+		fmt.Fprintf(b, "Dealt to %s [%s] [%s]\n", s.Name, formatCards(s.Up[:up-1]), formatCards(s.Up[up-1:up]))
+	}
+}
+
+func starsPosition(hh *HandHistory, seat int) string {
+	pos := ""
+	if seat == hh.Button && hh.Game != studGame {
+		pos += " (button)"
+	}
+	for _, a := range hh.Actions {
+		switch {
+		case a.Seat != seat:
+		case a.Kind == PostSmallBlind:
+			pos += " (small blind)"
+		case a.Kind == PostBigBlind:
+			pos += " (big blind)"
+			return pos
+		}
+	}
+	return pos
+}
+
+func starsResult(hh *HandHistory, s SeatRecord, folded map[int]Street, shown map[int]ShowdownRecord, won map[int]int, amt func(int) string) string {
+	if street, ok := folded[s.Seat]; ok {
+		if street == Preflop {
+			return "folded before Flop"
+		}
+		return "folded on the " + starsSummaryStreets[street]
+	}
+	if sd, ok := shown[s.Seat]; ok {
+		if won[s.Seat] > 0 {
+			return fmt.Sprintf("showed [%s] and won (%s) with %s", formatCards(s.Cards), amt(won[s.Seat]), sd.Description)
+		}
+		return fmt.Sprintf("showed [%s] and lost with %s", formatCards(s.Cards), sd.Description)
+	}
+	if won[s.Seat] > 0 {
+		return fmt.Sprintf("collected (%s)", amt(won[s.Seat]))
+	}
+	return "mucked"
+}
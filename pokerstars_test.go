@@ -0,0 +1,273 @@
+package poker
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const starsHands = `PokerStars Hand #209876543210:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/01/02 12:34:56 ET
+Table 'Andromeda V' 6-max Seat #2 is the button
+Seat 1: alice ($2 in chips)
+Seat 2: bob b ($1.50 in chips)
+Seat 3: carol ($2.13 in chips)
+Seat 5: dave ($0.80 in chips) is sitting out
+carol: posts small blind $0.01
+alice: posts big blind $0.02
+*** HOLE CARDS ***
+Dealt to alice [Ah Kh]
+bob b: raises $0.04 to $0.06
+carol: folds
+alice: raises $0.14 to $0.20
+bob b: calls $0.14
+*** FLOP *** [2h 7h Jc]
+alice: bets $0.30
+bob b: folds
+Uncalled bet ($0.30) returned to alice
+alice collected $0.41 from pot
+alice: doesn't show hand
+*** SUMMARY ***
+Total pot $0.41 | Rake $0
+Board [2h 7h Jc]
+Seat 1: alice (big blind) collected ($0.41)
+Seat 2: bob b (button) folded on the Flop
+Seat 3: carol (small blind) folded before Flop
+
+
+
+PokerStars Hand #210000000001: Tournament #3000000001, $1.00+$0.10 USD Hold'em No Limit - Level III (25/50) - 2020/01/02 13:00:00 ET
+Table '3000000001 1' 9-max Seat #1 is the button
+Seat 1: ann (1500 in chips)
+Seat 2: ben (400 in chips)
+Seat 4: cat (3,000 in chips)
+ann: posts the ante 5
+ben: posts the ante 5
+cat: posts the ante 5
+ben: posts small blind 25
+cat: posts big blind 50
+*** HOLE CARDS ***
+Dealt to ann [Qs Qd]
+ann: raises 100 to 150
+ben: raises 245 to 395 and is all-in
+cat: folds
+ann: calls 245
+*** FLOP *** [3c 8d Ks]
+*** TURN *** [3c 8d Ks] [2h]
+*** RIVER *** [3c 8d Ks 2h] [Qc]
+*** SHOW DOWN ***
+ann: shows [Qs Qd] (three of a kind, Queens)
+ben: shows [Ah Kh] (a pair of Kings)
+ann collected 855 from pot
+*** SUMMARY ***
+Total pot 855 | Rake 0
+Board [3c 8d Ks 2h Qc]
+Seat 1: ann (button) showed [Qs Qd] and won (855) with three of a kind, Queens
+Seat 2: ben (small blind) showed [Ah Kh] and lost with a pair of Kings
+Seat 4: cat (big blind) folded before Flop
+
+PokerStars Hand #210000000002:  Omaha Pot Limit ($0.05/$0.10 USD) - 2020/01/02 14:00:00 ET
+Table 'Omaha Test' 6-max Seat #3 is the button
+Seat 1: xa ($10 in chips)
+Seat 3: xb ($10 in chips)
+xb: posts small blind $0.05
+xa: posts big blind $0.10
+*** HOLE CARDS ***
+Dealt to xa [Ah As Kd Qc]
+xb: calls $0.05
+xa: checks
+*** FLOP *** [Ad 7c 2s]
+xa: bets $0.20
+xb: calls $0.20
+*** TURN *** [Ad 7c 2s] [9h]
+xa: checks
+xb: checks
+*** RIVER *** [Ad 7c 2s 9h] [3d]
+xa: bets $0.60
+xb: calls $0.60
+*** SHOW DOWN ***
+xa: shows [Ah As Kd Qc] (three of a kind, Aces)
+xb: shows [5h 4h Jc Js] (a straight, Ace to Five)
+xb collected $1.71 from pot
+*** SUMMARY ***
+Total pot $1.80 | Rake $0.09
+
+PokerStars Hand #210000000003:  7 Card Stud Limit ($0.10/$0.20 USD) - 2020/01/02 15:00:00 ET
+Table 'Stud Test' 8-max
+Seat 1: s1 ($5 in chips)
+Seat 2: s2 ($5 in chips)
+s1: posts the ante $0.02
+s2: posts the ante $0.02
+*** 3rd STREET ***
+Dealt to s1 [9s 9c 4d]
+Dealt to s2 [Kh]
+s1: brings in for $0.05
+s2: completes it to $0.10
+s1: calls $0.05
+*** 4th STREET ***
+Dealt to s1 [9s 9c 4d] [2c]
+Dealt to s2 [Kh] [7d]
+s2: bets $0.10
+s1: calls $0.10
+*** 5th STREET ***
+Dealt to s1 [9s 9c 4d 2c] [Jh]
+Dealt to s2 [Kh 7d] [3s]
+s2: checks
+s1: checks
+*** 6th STREET ***
+Dealt to s1 [9s 9c 4d 2c Jh] [5c]
+Dealt to s2 [Kh 7d 3s] [8h]
+s2: checks
+s1: checks
+*** RIVER ***
+Dealt to s1 [9s 9c 4d 2c Jh 5c] [Ts]
+s2: checks
+s1: checks
+*** SHOW DOWN ***
+s2: shows [Ac Ad Kh 7d 3s 8h 6h] (a pair of Aces)
+s1: shows [9s 9c 4d 2c Jh 5c Ts] (a pair of Nines)
+s2 collected $0.44 from pot
+*** SUMMARY ***
+Total pot $0.44 | Rake $0
+`
+
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func Test_readPokerStars(t *testing.T) {
+	hands, err := readPokerStars(strings.NewReader(starsHands))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hands) != 4 {
+		t.Fatalf("read %d hands, want 4", len(hands))
+	}
+
+	cash := hands[0]
+	if cash.Game != holdemGame || cash.Currency != "USD" || cash.Small != 1 || cash.Big != 2 || cash.Button != 1 || cash.Hero != "alice" {
+		t.Errorf("cash header = %+v", cash)
+	}
+	if got := toJSON(t, cash.Seats); got != `[{"seat":0,"name":"alice","stack":200,"cards":["Ah","Kh"]},{"seat":1,"name":"bob b","stack":150},{"seat":2,"name":"carol","stack":213}]` {
+		t.Errorf("cash seats = %s", got)
+	}
+	if got, want := toJSON(t, cash.Actions[2:5]), `[{"street":"Preflop","seat":1,"kind":"Raise","amount":6,"to":6},{"street":"Preflop","seat":2,"kind":"Fold"},{"street":"Preflop","seat":0,"kind":"Raise","amount":18,"to":20}]`; got != want {
+		t.Errorf("cash actions = %s, want %s", got, want)
+	}
+	// the uncalled bet comes back in the same award as the pot
+	if got := toJSON(t, cash.Awards); got != `[{"seat":0,"amount":71}]` {
+		t.Errorf("cash awards = %s", got)
+	}
+
+	tourney := hands[1]
+	if tourney.Tournament == nil || tourney.Tournament.ID != 3000000001 || tourney.Tournament.Level != "III" || tourney.Ante != 5 || tourney.Big != 50 {
+		t.Errorf("tournament header = %+v %+v", tourney, tourney.Tournament)
+	}
+	if got := toJSON(t, tourney.Showdown); got != `[{"seat":0,"category":"ThreeOfAKind","description":"three of a kind, Queens"},{"seat":1,"category":"Pair","description":"a pair of Kings"}]` {
+		t.Errorf("tournament showdown = %s", got)
+	}
+
+	if got := hands[2].Showdown[1].Category; got != Straight {
+		t.Errorf("Omaha wheel = %s, want Straight", got)
+	}
+	if got := hands[2].Showdown[0].Category; got != ThreeOfAKind {
+		t.Errorf("Omaha aces = %s, want ThreeOfAKind", got)
+	}
+
+	stud := hands[3]
+	if stud.Hero != "s1" || len(stud.Seats[0].Cards) != 7 || toJSON(t, stud.Seats[1].Up) != `["Kh","7d","3s","8h"]` {
+		t.Errorf("stud seats = %+v", stud.Seats)
+	}
+	if a := stud.Actions[3]; a.Street != ThirdStreet || a.Kind != Raise || a.To != 10 {
+		t.Errorf("stud completion = %+v", a)
+	}
+	if a := stud.Actions[len(stud.Actions)-1]; a.Street != SeventhStreet {
+		t.Errorf("last stud action on %s, want SeventhStreet", a.Street)
+	}
+}
+
+func Test_writePokerStars(t *testing.T) {
+	hands, err := readPokerStars(strings.NewReader(starsHands))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hh := range hands {
+		var b strings.Builder
+		if err := writePokerStars(&b, hh); err != nil {
+			t.Fatal(err)
+		}
+		again, err := readPokerStars(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("hand %d: %v\n%s", hh.Number, err, b.String())
+		}
+		if got, want := toJSON(t, again[0]), toJSON(t, hh); got != want {
+			t.Errorf("hand %d does not round trip:\n%s\ngot  %s\nwant %s", hh.Number, b.String(), got, want)
+		}
+	}
+
+	// the first hand comes out as it went in, bar the summary
+	var b strings.Builder
+	if err := writePokerStars(&b, hands[0]); err != nil {
+		t.Fatal(err)
+	}
+	want := starsHands[:strings.Index(starsHands, "*** SUMMARY ***")]
+	want = strings.Replace(want, "Seat 5: dave ($0.80 in chips) is sitting out\n", "", 1)
+	want = strings.Replace(want, "alice: doesn't show hand\n", "", 1)
+	if got := b.String()[:len(want)]; got != want {
+		t.Errorf("writePokerStars() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_writePokerStars_simulated(t *testing.T) {
+	histories, _ := recordHoldem(t, 11, 30)
+
+	var b strings.Builder
+	for _, hh := range histories {
+		if err := writePokerStars(&b, hh); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read, err := readPokerStars(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != len(histories) {
+		t.Fatalf("read %d hands back, want %d", len(read), len(histories))
+	}
+	for i, hh := range histories {
+		got := read[i]
+		won := func(hh *HandHistory) map[int]int {
+			m := make(map[int]int)
+			for _, a := range hh.Awards {
+				m[a.Seat] += a.Amount
+			}
+			return m
+		}
+		// the text format lists seats by number, not in the order dealt
+		seats := append([]SeatRecord(nil), hh.Seats...)
+		sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+		for _, check := range []struct {
+			name      string
+			got, want any
+		}{
+			{"seats", got.Seats, seats},
+			{"board", got.Board, hh.Board},
+			{"actions", got.Actions, hh.Actions},
+			{"showdown", got.Showdown, hh.Showdown},
+			{"awards", won(got), won(hh)},
+		} {
+			if g, w := toJSON(t, check.got), toJSON(t, check.want); g != w {
+				t.Errorf("hand %d %s = %s, want %s", hh.Number, check.name, g, w)
+			}
+		}
+	}
+
+	if err := writePokerStars(&b, &HandHistory{Game: fiveCardGame}); err == nil {
+		t.Error("writePokerStars() of a five-card hand succeeded, want an error")
+	}
+}
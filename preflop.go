@@ -0,0 +1,287 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// rankChars are the one-letter names of the ranks from the deuce up.
+const rankChars = "23456789TJQKA"
+
+// StartingHand is one of the 169 classes of two hole cards: a pair, or two
+// ranks suited or offsuit. high is never below low.
+type StartingHand struct {
+	high   Rank
+	low    Rank
+	suited bool
+}
+
+// startingHands lists the 169 classes, pairs first and then by high and low
+// rank, aces first.
+func startingHands() []StartingHand {
+	hands := make([]StartingHand, 0, 169)
+	for r := Ace; r >= Two; r-- {
+		hands = append(hands, StartingHand{high: r, low: r})
+	}
+	for high := Ace; high >= Three; high-- {
+		for low := high - 1; low >= Two; low-- {
+			hands = append(hands, StartingHand{high: high, low: low, suited: true})
+			hands = append(hands, StartingHand{high: high, low: low})
+		}
+	}
+
+	return hands
+}
+
+func (s StartingHand) pair() bool {
+	return s.high == s.low
+}
+
+// combos returns how many ways the class can be dealt: six for a pair, four
+// suited and twelve offsuit.
+func (s StartingHand) combos() int {
+	switch {
+	case s.pair():
+		return 6
+	case s.suited:
+		return 4
+	}
+	return 12
+}
+
+// holeCards lists every way the class can be dealt.
+func (s StartingHand) holeCards() [][2]Card {
+	hands := make([][2]Card, 0, s.combos())
+	for a := Spade; a <= Heart; a++ {
+		for b := Spade; b <= Heart; b++ {
+			switch {
+			case s.pair() && b <= a:
+			case !s.pair() && s.suited != (a == b):
+			default:
+				hands = append(hands, [2]Card{{rank: s.high, suit: a}, {rank: s.low, suit: b}})
+			}
+		}
+	}
+
+	return hands
+}
+
+func (s StartingHand) String() string {
+	buf := string(rankChars[s.high-2]) + string(rankChars[s.low-2])
+	switch {
+	case s.pair():
+		return buf
+	case s.suited:
+		return buf + "s"
+	}
+	return buf + "o"
+}
+
+// equityFunc returns a hand's share of the pot all in before the flop
+// against a player holding any of the given classes, each dealt as often as
+// it has combos.
+type equityFunc func(hand StartingHand, against []StartingHand) float64
+
+// sampledEquity estimates equities by dealing the given number of random
+// matchups and boards.
+func sampledEquity(r *rand.Rand, trials int) equityFunc {
+	return func(hand StartingHand, against []StartingHand) float64 {
+		villains := make([][2]Card, 0)
+		for _, a := range against {
+			villains = append(villains, a.holeCards()...)
+		}
+		heroes := hand.holeCards()
+
+		won := 0.0
+		for i := 0; i < trials; {
+			hero := heroes[r.Intn(len(heroes))]
+			villain := villains[r.Intn(len(villains))]
+			used := map[Card]bool{hero[0]: true, hero[1]: true}
+			if used[villain[0]] || used[villain[1]] {
+				continue
+			}
+			used[villain[0]], used[villain[1]] = true, true
+			var h, v suitMasks
+			h.add(hero[0])
+			h.add(hero[1])
+			v.add(villain[0])
+			v.add(villain[1])
+			for n := 0; n < 5; {
+				c := Card{rank: Rank(r.Intn(13) + 2), suit: Suit(r.Intn(4))}
+				if used[c] {
+					continue
+				}
+				used[c] = true
+				h.add(c)
+				v.add(c)
+				n++
+			}
+			switch hv, vv := h.value(), v.value(); {
+			case hv > vv:
+				won++
+			case hv == vv:
+				won += 0.5
+			}
+			i++
+		}
+
+		return won / float64(trials)
+	}
+}
+
+// index returns the class's position in startingHands.
+func (s StartingHand) index() int {
+	if s.pair() {
+		return int(Ace - s.high)
+	}
+	before := 0
+	for h := Ace; h > s.high; h-- {
+		before += int(h - 2)
+	}
+	i := 13 + 2*(before+int(s.high-1-s.low))
+	if !s.suited {
+		i++
+	}
+	return i
+}
+
+// equityMatrix holds the all-in equity of every class against every other,
+// and how many ways each matchup can be dealt without sharing a card.
+type equityMatrix struct {
+	equity [169][169]float64
+	weight [169][169]int
+}
+
+func newEquityMatrix() *equityMatrix {
+	m := &equityMatrix{}
+	hands := startingHands()
+	for i, a := range hands {
+		for j, b := range hands {
+			for _, x := range a.holeCards() {
+				for _, y := range b.holeCards() {
+					if x[0] != y[0] && x[0] != y[1] && x[1] != y[0] && x[1] != y[1] {
+						m.weight[i][j]++
+					}
+				}
+			}
+		}
+	}
+	return m
+}
+
+// sampleMatchup deals random combos of two classes and random boards and
+// returns the first class's share of the pots.
+func sampleMatchup(r *rand.Rand, as, bs [][2]Card, trials int) float64 {
+	won := 0.0
+	for i := 0; i < trials; {
+		a, b := as[r.Intn(len(as))], bs[r.Intn(len(bs))]
+		if a[0] == b[0] || a[0] == b[1] || a[1] == b[0] || a[1] == b[1] {
+			continue
+		}
+		var used [4]uint16
+		var h, v suitMasks
+		for _, c := range []Card{a[0], a[1]} {
+			h.add(c)
+			used[c.suit] |= 1 << (c.rank - 2)
+		}
+		for _, c := range []Card{b[0], b[1]} {
+			v.add(c)
+			used[c.suit] |= 1 << (c.rank - 2)
+		}
+		for n := 0; n < 5; {
+			k := r.Intn(52)
+			c := Card{rank: Rank(k%13 + 2), suit: Suit(k / 13)}
+			if used[c.suit]&(1<<(c.rank-2)) != 0 {
+				continue
+			}
+			used[c.suit] |= 1 << (c.rank - 2)
+			h.add(c)
+			v.add(c)
+			n++
+		}
+		switch hv, vv := h.value(), v.value(); {
+		case hv > vv:
+			won++
+		case hv == vv:
+			won += 0.5
+		}
+		i++
+	}
+
+	return won / float64(trials)
+}
+
+// versus returns the equity of class i against a range given as the
+// frequency each class is in it, and how many of the 1,225 combos left once
+// the hand's own cards are out the range holds on average.
+func (m *equityMatrix) versus(i int, freq *[169]float64) (equity, combos float64) {
+	for j, f := range freq {
+		if f == 0 || m.weight[i][j] == 0 {
+			continue
+		}
+		w := f * float64(m.weight[i][j])
+		equity += w * m.equity[i][j]
+		combos += w
+	}
+	if combos == 0 {
+		return 0, 0
+	}
+	return equity / combos, combos / float64(startingHands()[i].combos())
+}
+
+// against adapts the matrix to an equityFunc.
+func (m *equityMatrix) against(hand StartingHand, against []StartingHand) float64 {
+	var freq [169]float64
+	for _, a := range against {
+		freq[a.index()] = 1
+	}
+	e, _ := m.versus(hand.index(), &freq)
+	return e
+}
+
+// chart lays a frequency for each class out on the usual 13 by 13 grid:
+// aces in the first row and column, suited hands above the pairs on the
+// diagonal and offsuit hands below.
+type chart [13][13]float64
+
+func (s StartingHand) cell() (row, col int) {
+	hi, lo := int(Ace-s.high), int(Ace-s.low)
+	if s.suited {
+		return hi, lo
+	}
+	return lo, hi
+}
+
+// chartOf lays out frequencies given in startingHands order.
+func chartOf(freq *[169]float64) chart {
+	var c chart
+	for i, h := range startingHands() {
+		row, col := h.cell()
+		c[row][col] = freq[i]
+	}
+	return c
+}
+
+// String shows the classes played at least half the time by name.
+func (c chart) String() string {
+	buf := ""
+	for row := range c {
+		for col := range c[row] {
+			h := StartingHand{high: Ace - Rank(row), low: Ace - Rank(col), suited: col > row}
+			if col < row {
+				h.high, h.low = h.low, h.high
+			}
+			name := "  -"
+			if c[row][col] >= 0.5 {
+				name = fmt.Sprintf("%3s", h)
+			}
+			buf += name
+			if col < 12 {
+				buf += " "
+			}
+		}
+		buf += "\n"
+	}
+
+	return buf
+}
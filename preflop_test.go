@@ -0,0 +1,76 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_startingHands(t *testing.T) {
+	hands := startingHands()
+	if len(hands) != 169 {
+		t.Fatalf("startingHands() = %d classes, want 169", len(hands))
+	}
+	seen := make(map[[2]Card]bool)
+	combos := 0
+	for _, h := range hands {
+		for _, cards := range h.holeCards() {
+			if seen[cards] || cards[0] == cards[1] {
+				t.Errorf("%s deals %v twice", h, cards)
+			}
+			seen[cards] = true
+		}
+		if len(h.holeCards()) != h.combos() {
+			t.Errorf("%s deals %d combos, want %d", h, len(h.holeCards()), h.combos())
+		}
+		combos += h.combos()
+	}
+	if combos != 1326 {
+		t.Errorf("combos = %d, want 1326", combos)
+	}
+	if got := hands[0].String() + hands[13].String() + hands[168].String(); got != "AAAKs32o" {
+		t.Errorf("String() = %s, want AA, AKs and 32o", got)
+	}
+}
+
+func Test_sampledEquity(t *testing.T) {
+	equity := sampledEquity(rand.New(rand.NewSource(1)), 20000)
+	tests := []struct {
+		hand, against StartingHand
+		want          float64
+	}{
+		{StartingHand{high: Ace, low: Ace}, StartingHand{high: King, low: King}, 0.82},
+		{StartingHand{high: Ace, low: King, suited: true}, StartingHand{high: Queen, low: Queen}, 0.46},
+		{StartingHand{high: Seven, low: Two}, StartingHand{high: Seven, low: Two}, 0.5},
+	}
+	for _, tt := range tests {
+		if got := equity(tt.hand, []StartingHand{tt.against}); got < tt.want-0.015 || got > tt.want+0.015 {
+			t.Errorf("equity(%s, %s) = %.3f, want about %.2f", tt.hand, tt.against, got, tt.want)
+		}
+	}
+}
+
+func TestStartingHand_index(t *testing.T) {
+	for i, h := range startingHands() {
+		if got := h.index(); got != i {
+			t.Errorf("%s index() = %d, want %d", h, got, i)
+		}
+	}
+}
+
+func Test_equityMatrix(t *testing.T) {
+	m := newEquityMatrix()
+	aces, kings, aks := StartingHand{high: Ace, low: Ace}.index(), StartingHand{high: King, low: King}.index(), StartingHand{high: Ace, low: King, suited: true}.index()
+	tests := []struct {
+		a, b, want int
+	}{
+		{aces, aces, 6},
+		{aces, kings, 36},
+		{aces, aks, 12},
+		{aks, aks, 12},
+	}
+	for _, tt := range tests {
+		if got := m.weight[tt.a][tt.b]; got != tt.want {
+			t.Errorf("weight[%d][%d] = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
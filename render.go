@@ -0,0 +1,166 @@
+package poker
+
+import "strings"
+
+// suitChars are the one-letter names of the suits in Suit order.
+const suitChars = "sdch"
+
+// suitSymbols are the Unicode suit symbols in Suit order.
+var suitSymbols = [4]string{"♠", "♦", "♣", "♥"}
+
+// Renderer turns cards into text. CLIs and logs pick the one that suits
+// their output.
+type Renderer interface {
+	Render(cards []Card) string
+}
+
+var (
+	// Compact writes cards as rank and suit letters: "As Kd".
+	Compact Renderer = compactRenderer{}
+	// Symbols writes cards with Unicode suit symbols: "A♠ K♦".
+	Symbols Renderer = symbolRenderer{}
+	// CodePoints writes each card as its own Unicode playing card: "🂡 🃎".
+	CodePoints Renderer = codePointRenderer{}
+	// ASCIIArt draws each card as a small box, side by side over five lines.
+	ASCIIArt Renderer = asciiArtRenderer{}
+)
+
+// ANSI writes cards with suit symbols in terminal colors: hearts and
+// diamonds red, or with a four-color deck diamonds blue and clubs green.
+func ANSI(fourColor bool) Renderer {
+	return ansiRenderer{fourColor: fourColor}
+}
+
+func joinCards(cards []Card, name func(Card) string) string {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = name(c)
+	}
+	return strings.Join(names, " ")
+}
+
+// jokerText is how the text renderers write the joker.
+const jokerText = "Jk"
+
+func rankChar(r Rank) byte {
+	return rankChars[r-2]
+}
+
+type compactRenderer struct{}
+
+func (compactRenderer) Render(cards []Card) string {
+	return joinCards(cards, func(c Card) string {
+		if c == joker {
+			return jokerText
+		}
+		return string([]byte{rankChar(c.rank), suitChars[c.suit]})
+	})
+}
+
+type symbolRenderer struct{}
+
+func (symbolRenderer) Render(cards []Card) string {
+	return joinCards(cards, func(c Card) string {
+		if c == joker {
+			return jokerText
+		}
+		return string(rankChar(c.rank)) + suitSymbols[c.suit]
+	})
+}
+
+// playingCardBlocks are where each suit starts in the Unicode playing cards
+// block. The card's rank is added to it, the ace being one and the knight,
+// which the deck does not use, sitting between the jack and the queen.
+var playingCardBlocks = [4]rune{0x1F0A0, 0x1F0C0, 0x1F0D0, 0x1F0B0}
+
+// blackJoker is the joker's Unicode playing card.
+const blackJoker = 0x1F0CF
+
+type codePointRenderer struct{}
+
+func (codePointRenderer) Render(cards []Card) string {
+	return joinCards(cards, func(c Card) string {
+		if c == joker {
+			return string(rune(blackJoker))
+		}
+		r := rune(c.rank)
+		switch {
+		case c.rank == Ace:
+			r = 1
+		case c.rank >= Queen:
+			r++
+		}
+		return string(playingCardBlocks[c.suit] + r)
+	})
+}
+
+const ansiReset = "\x1b[0m"
+
+type ansiRenderer struct {
+	fourColor bool
+}
+
+func (a ansiRenderer) color(s Suit) string {
+	switch {
+	case s == Heart:
+		return "\x1b[31m"
+	case s == Diamond && a.fourColor:
+		return "\x1b[34m"
+	case s == Diamond:
+		return "\x1b[31m"
+	case s == Club && a.fourColor:
+		return "\x1b[32m"
+	}
+	return "\x1b[39m"
+}
+
+func (a ansiRenderer) Render(cards []Card) string {
+	return joinCards(cards, func(c Card) string {
+		if c == joker {
+			return jokerText
+		}
+		return a.color(c.suit) + string(rankChar(c.rank)) + suitSymbols[c.suit] + ansiReset
+	})
+}
+
+type asciiArtRenderer struct{}
+
+// Render draws the cards as
+//
+//	+-----+ +-----+
+//	|A    | |K    |
+//	|  s  | |  d  |
+//	|    A| |    K|
+//	+-----+ +-----+
+func (asciiArtRenderer) Render(cards []Card) string {
+	var lines [5][]string
+	for _, c := range cards {
+		top, middle, bottom := "|"+jokerText+"   |", "|  *  |", "|   "+jokerText+"|"
+		if c != joker {
+			r, s := string(rankChar(c.rank)), string(suitChars[c.suit])
+			top, middle, bottom = "|"+r+"    |", "|  "+s+"  |", "|    "+r+"|"
+		}
+		lines[0] = append(lines[0], "+-----+")
+		lines[1] = append(lines[1], top)
+		lines[2] = append(lines[2], middle)
+		lines[3] = append(lines[3], bottom)
+		lines[4] = append(lines[4], "+-----+")
+	}
+	rows := make([]string, len(lines))
+	for i, l := range lines {
+		rows[i] = strings.Join(l, " ")
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// render writes the hand's cards with the given renderer.
+func (h *Hand) render(r Renderer) string {
+	return r.Render(h.cards[:])
+}
+
+// String writes a card as its rank and suit letters, "As", or the joker as
+// "Jk".
+func (c Card) String() string {
+	return Compact.Render([]Card{c})
+}
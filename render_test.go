@@ -0,0 +1,67 @@
+package poker
+
+import "testing"
+
+func TestRenderer(t *testing.T) {
+	cards := []Card{{Ace, Spade}, {King, Diamond}, {Ten, Club}, {Queen, Heart}}
+	tests := []struct {
+		name     string
+		renderer Renderer
+		want     string
+	}{
+		{"compact", Compact, "As Kd Tc Qh"},
+		{"symbols", Symbols, "A♠ K♦ T♣ Q♥"},
+		{"code points", CodePoints, "\U0001F0A1 \U0001F0CE \U0001F0DA \U0001F0BD"},
+		{"two colors", ANSI(false), "\x1b[39mA♠\x1b[0m \x1b[31mK♦\x1b[0m \x1b[39mT♣\x1b[0m \x1b[31mQ♥\x1b[0m"},
+		{"four colors", ANSI(true), "\x1b[39mA♠\x1b[0m \x1b[34mK♦\x1b[0m \x1b[32mT♣\x1b[0m \x1b[31mQ♥\x1b[0m"},
+		{"ascii art", ASCIIArt, "" +
+			"+-----+ +-----+ +-----+ +-----+\n" +
+			"|A    | |K    | |T    | |Q    |\n" +
+			"|  s  | |  d  | |  c  | |  h  |\n" +
+			"|    A| |    K| |    T| |    Q|\n" +
+			"+-----+ +-----+ +-----+ +-----+"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.renderer.Render(cards); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	h := Hand{cards: [5]Card{{Two, Heart}, {Ace, Spade}, {Five, Club}, {Nine, Diamond}, {Two, Club}}}
+	h.score()
+	if got := h.render(Compact); got != "As 9d 5c 2h 2c" {
+		t.Errorf("render() = %q, want %q", got, "As 9d 5c 2h 2c")
+	}
+}
+
+func TestRenderer_joker(t *testing.T) {
+	cards := []Card{{Ace, Spade}, joker}
+	tests := []struct {
+		name     string
+		renderer Renderer
+		want     string
+	}{
+		{"compact", Compact, "As Jk"},
+		{"symbols", Symbols, "A♠ Jk"},
+		{"code points", CodePoints, "\U0001F0A1 \U0001F0CF"},
+		{"ansi", ANSI(true), "\x1b[39mA♠\x1b[0m Jk"},
+		{"ascii art", ASCIIArt, "" +
+			"+-----+ +-----+\n" +
+			"|A    | |Jk   |\n" +
+			"|  s  | |  *  |\n" +
+			"|    A| |   Jk|\n" +
+			"+-----+ +-----+"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.renderer.Render(cards); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := joker.String(); got != "Jk" {
+		t.Errorf("String() = %q, want Jk", got)
+	}
+}
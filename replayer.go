@@ -0,0 +1,325 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// replayFrame is the table at one point in a recorded hand.
+type replayFrame struct {
+	street Street
+	// action is what was just done, or nil when cards were dealt or the
+	// pots paid
+	action *Action
+	stacks map[int]int
+	// bets are the live chips in front of each seat this street and paid
+	// everything each has put in
+	bets   map[int]int
+	paid   map[int]int
+	folded map[int]bool
+	board  []Card
+	// dealt is how many cards each seat has been dealt
+	dealt int
+	// won is what each seat took, once the pots are paid
+	won map[int]int
+}
+
+func (f *replayFrame) clone() replayFrame {
+	c := *f
+	c.stacks, c.bets, c.paid = copyInts(f.stacks), copyInts(f.bets), copyInts(f.paid)
+	c.folded = make(map[int]bool, len(f.folded))
+	for s, v := range f.folded {
+		c.folded[s] = v
+	}
+	return c
+}
+
+func copyInts(m map[int]int) map[int]int {
+	c := make(map[int]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// replayer steps through a recorded hand. It starts before the antes and
+// blinds and ends once the pots are paid, stopping after each action and
+// whenever cards are dealt.
+type replayer struct {
+	hh     *HandHistory
+	frames []replayFrame
+	pos    int
+	shown  map[int]bool
+}
+
+// holeCards is how many cards each game deals before the first betting
+// round.
+var holeCards = map[string]int{fiveCardGame: 5, holdemGame: 2, omahaGame: 4, studGame: 3}
+
+func newReplayer(hh *HandHistory) (*replayer, error) {
+	dealt, ok := holeCards[hh.Game]
+	if !ok {
+		return nil, fmt.Errorf("hand %d: unknown game %q", hh.Number, hh.Game)
+	}
+	r := &replayer{hh: hh, shown: make(map[int]bool)}
+	for _, sd := range hh.Showdown {
+		r.shown[sd.Seat] = true
+	}
+	first, last := hh.streets()
+	cur := replayFrame{
+		street: first,
+		stacks: make(map[int]int),
+		bets:   make(map[int]int),
+		paid:   make(map[int]int),
+		folded: make(map[int]bool),
+	}
+	for _, s := range hh.Seats {
+		cur.stacks[s.Seat] = s.Stack
+	}
+	push := func() {
+		r.frames = append(r.frames, cur.clone())
+	}
+	apply := func(a Action) error {
+		stack, ok := cur.stacks[a.Seat]
+		switch {
+		case !ok:
+			return fmt.Errorf("hand %d: no player in seat %d", hh.Number, a.Seat)
+		case a.Amount < 0 || a.Amount > stack:
+			return fmt.Errorf("hand %d: seat %d cannot put in %d with %d", hh.Number, a.Seat, a.Amount, stack)
+		}
+		cur.stacks[a.Seat] -= a.Amount
+		cur.paid[a.Seat] += a.Amount
+		if a.Kind != PostAnte && a.Kind != PostDead {
+			cur.bets[a.Seat] += a.Amount
+		}
+		if a.Kind == Fold {
+			cur.folded[a.Seat] = true
+		}
+		cur.action = &a
+		push()
+		return nil
+	}
+
+	push()
+	i := 0
+	for ; i < len(hh.Actions) && hh.Actions[i].Kind.post() && hh.Actions[i].Kind != PostBringIn; i++ {
+		if err := apply(hh.Actions[i]); err != nil {
+			return nil, err
+		}
+	}
+	for street := first; street <= last; street++ {
+		cur.street, cur.action = street, nil
+		if street == first {
+			cur.dealt = dealt
+		} else {
+			cur.bets = make(map[int]int)
+			if hh.Game == studGame {
+				cur.dealt++
+			} else if n := 3 + int(street-Flop); n <= len(hh.Board) {
+				cur.board = hh.Board[:n]
+			}
+		}
+		push()
+		for ; i < len(hh.Actions) && hh.Actions[i].Street == street; i++ {
+			if err := apply(hh.Actions[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if i < len(hh.Actions) {
+		return nil, fmt.Errorf("hand %d: action %d on the %s is out of order", hh.Number, i, hh.Actions[i].Street)
+	}
+
+	cur.action, cur.bets, cur.won = nil, make(map[int]int), make(map[int]int)
+	for _, a := range hh.Awards {
+		if _, ok := cur.stacks[a.Seat]; !ok {
+			return nil, fmt.Errorf("hand %d: award to empty seat %d", hh.Number, a.Seat)
+		}
+		cur.stacks[a.Seat] += a.Amount
+		cur.won[a.Seat] += a.Amount
+	}
+	push()
+
+	return r, nil
+}
+
+// frame returns the table as it is at the current step.
+func (r *replayer) frame() *replayFrame {
+	return &r.frames[r.pos]
+}
+
+// steps returns how many steps there are after the first.
+func (r *replayer) steps() int {
+	return len(r.frames) - 1
+}
+
+// forward moves to the next step, reporting false at the end of the hand.
+func (r *replayer) forward() bool {
+	if r.pos == r.steps() {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+// back moves to the previous step, reporting false at the start.
+func (r *replayer) back() bool {
+	if r.pos == 0 {
+		return false
+	}
+	r.pos--
+	return true
+}
+
+// seek moves to a step.
+func (r *replayer) seek(step int) error {
+	if step < 0 || step > r.steps() {
+		return fmt.Errorf("no step %d in a hand of %d", step, r.steps())
+	}
+	r.pos = step
+	return nil
+}
+
+// pots returns the pot, split into side pots once someone still in the hand
+// is all in. There are none once they are paid.
+func (f *replayFrame) pots(hh *HandHistory) []int {
+	if f.won != nil {
+		return nil
+	}
+	live := make([]int, 0, len(hh.Seats))
+	allIn := false
+	total := 0
+	for _, s := range hh.Seats {
+		total += f.paid[s.Seat]
+		if !f.folded[s.Seat] {
+			live = append(live, s.Seat)
+			allIn = allIn || f.stacks[s.Seat] == 0
+		}
+	}
+	if !allIn || len(live) == 0 {
+		return []int{total}
+	}
+	h := &TableHand{paid: f.paid}
+	pots := make([]int, 0)
+	for _, p := range h.sidePots(live) {
+		pots = append(pots, p.amount)
+	}
+	return pots
+}
+
+// visible returns the cards each seat shows to the player in the viewer's
+// seat, or to someone watching when viewer is -1: the viewer's own cards,
+// the face-up stud cards of players still in and, once the pots are paid,
+// the hands shown down. Cards the history does not know are left out.
+func (r *replayer) visible(viewer int) map[int][]Card {
+	f := r.frame()
+	cards := make(map[int][]Card)
+	for _, s := range r.hh.Seats {
+		var seen []Card
+		switch {
+		case s.Seat == viewer || f.won != nil && r.shown[s.Seat]:
+			seen = dealtCards(r.hh, s, f.dealt)
+		case r.hh.Game == studGame && !f.folded[s.Seat]:
+			seen = upCards(s, f.dealt)
+		}
+		if len(seen) > 0 {
+			cards[s.Seat] = seen
+		}
+	}
+	return cards
+}
+
+// dealtCards returns the cards a seat holds after dealt have been dealt.
+func dealtCards(hh *HandHistory, s SeatRecord, dealt int) []Card {
+	switch {
+	case dealt == 0:
+		return nil
+	case hh.Game != studGame:
+		return s.Cards
+	case len(s.Cards) >= dealt:
+		return s.Cards[:dealt]
+	}
+	return upCards(s, dealt)
+}
+
+// upCards returns a stud seat's face-up cards: the third to the sixth dealt.
+func upCards(s SeatRecord, dealt int) []Card {
+	up := clamp(dealt-2, 0, 4)
+	switch {
+	case up == 0:
+		return nil
+	case len(s.Up) > 0:
+		return s.Up[:clamp(up, 0, len(s.Up))]
+	case len(s.Cards) >= up+2:
+		return s.Cards[2 : up+2]
+	}
+	return nil
+}
+
+// snapshot captures the table at the current step as the viewer sees it,
+// for drawing with tableSnapshot.svg.
+func (r *replayer) snapshot(viewer int) *tableSnapshot {
+	f := r.frame()
+	visible := r.visible(viewer)
+	snap := &tableSnapshot{seats: make([]*seatView, r.hh.TableSize), board: f.board, pots: f.pots(r.hh)}
+	for _, s := range r.hh.Seats {
+		snap.seats[s.Seat] = &seatView{
+			name:   s.Name,
+			stack:  f.stacks[s.Seat],
+			bet:    f.bets[s.Seat],
+			inHand: !f.folded[s.Seat] && f.dealt > 0,
+			button: s.Seat == r.hh.Button && r.hh.Game != studGame,
+			cards:  visible[s.Seat],
+		}
+	}
+	return snap
+}
+
+// verify checks that the recorded seed deals the recorded cards the way the
+// engine that played the hand dealt them, and that the actions replay to the
+// same hand.
+func (r *replayer) verify() error {
+	hh := r.hh
+	var hole [][]Card
+	var board []Card
+	switch hh.Game {
+	case fiveCardGame:
+		hands, err := dealWith(rand.New(rand.NewSource(hh.Seed)), len(hh.Seats))
+		if err != nil {
+			return fmt.Errorf("hand %d: %w", hh.Number, err)
+		}
+		for i := range hands {
+			hole = append(hole, hands[i].cards[:])
+		}
+	case holdemGame:
+		var err error
+		if hole, board, err = dealHoldem(hh.Seed, len(hh.Seats)); err != nil {
+			return fmt.Errorf("hand %d: %w", hh.Number, err)
+		}
+	default:
+		return fmt.Errorf("hand %d: %s hands are not dealt from a seed", hh.Number, hh.Game)
+	}
+	for i, s := range hh.Seats {
+		if !sameCards(s.Cards, hole[i]) {
+			return fmt.Errorf("hand %d: seed %d deals seat %d [%s], not [%s]",
+				hh.Number, hh.Seed, s.Seat, formatCards(hole[i]), formatCards(s.Cards))
+		}
+	}
+	if len(hh.Board) > len(board) || !sameCards(hh.Board, board[:len(hh.Board)]) {
+		return fmt.Errorf("hand %d: seed %d deals the board [%s], not [%s]",
+			hh.Number, hh.Seed, formatCards(board), formatCards(hh.Board))
+	}
+	return replay(hh)
+}
+
+func sameCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
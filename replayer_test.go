@@ -0,0 +1,203 @@
+package poker
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_replayer(t *testing.T) {
+	histories, stacks := recordHoldem(t, 21, 30)
+	for n, hh := range histories {
+		r, err := newReplayer(hh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.verify(); err != nil {
+			t.Error(err)
+		}
+
+		var frames []replayFrame
+		var snaps []string
+		total := 0
+		for _, s := range hh.Seats {
+			total += s.Stack
+		}
+		for {
+			f := r.frame()
+			frames = append(frames, f.clone())
+			snaps = append(snaps, r.snapshot(-1).svg(false))
+			chips := 0
+			for _, s := range hh.Seats {
+				chips += f.stacks[s.Seat]
+			}
+			for _, p := range f.pots(hh) {
+				chips += p
+			}
+			if chips != total {
+				t.Fatalf("hand %d step %d: %d chips on the table, want %d", hh.Number, r.pos, chips, total)
+			}
+			if !r.forward() {
+				break
+			}
+		}
+		// the start, every action, every street dealt and the payout
+		first, last := hh.streets()
+		if want := len(hh.Actions) + int(last-first) + 3; len(frames) != want {
+			t.Errorf("hand %d: %d steps, want %d", hh.Number, len(frames), want)
+		}
+		for _, s := range hh.Seats {
+			if got, want := r.frame().stacks[s.Seat], stacks[n][s.Seat]; got != want {
+				t.Errorf("hand %d: seat %d ends with %d, want %d", hh.Number, s.Seat, got, want)
+			}
+		}
+
+		// stepping back retraces the hand
+		for i := len(frames) - 1; i >= 0; i-- {
+			if !reflect.DeepEqual(*r.frame(), frames[i]) || r.snapshot(-1).svg(false) != snaps[i] {
+				t.Fatalf("hand %d: step %d differs on the way back", hh.Number, i)
+			}
+			if r.back() != (i > 0) {
+				t.Fatalf("hand %d: back() at step %d", hh.Number, i)
+			}
+		}
+	}
+}
+
+func Test_replayer_visible(t *testing.T) {
+	histories, _ := recordHoldem(t, 4, 30)
+	var hh *HandHistory
+	for _, h := range histories {
+		if len(h.Showdown) > 1 && len(h.Seats) > 2 {
+			hh = h
+			break
+		}
+	}
+	if hh == nil {
+		t.Fatal("no hand went to a showdown")
+	}
+	r, err := newReplayer(hh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	me := hh.Seats[0].Seat
+	for r.frame().dealt == 0 {
+		if got := r.visible(me); len(got) != 0 {
+			t.Errorf("before the deal seat %d sees %v", me, got)
+		}
+		r.forward()
+	}
+	if got := r.visible(me); len(got) != 1 || !sameCards(got[me], hh.Seats[0].Cards) {
+		t.Errorf("after the deal seat %d sees %v, want only its own %v", me, got, hh.Seats[0].Cards)
+	}
+	if got := r.visible(-1); len(got) != 0 {
+		t.Errorf("after the deal a spectator sees %v", got)
+	}
+	if err := r.seek(r.steps()); err != nil {
+		t.Fatal(err)
+	}
+	got := r.visible(-1)
+	for _, sd := range hh.Showdown {
+		if !sameCards(got[sd.Seat], hh.seat(sd.Seat).Cards) {
+			t.Errorf("at the end seat %d shows %v, want %v", sd.Seat, got[sd.Seat], hh.seat(sd.Seat).Cards)
+		}
+	}
+	if len(got) != len(hh.Showdown) {
+		t.Errorf("at the end %d hands are visible, want the %d shown", len(got), len(hh.Showdown))
+	}
+	if !sameCards(r.frame().board, hh.Board) {
+		t.Errorf("final board = %v, want %v", r.frame().board, hh.Board)
+	}
+	if err := r.seek(r.steps() + 1); err == nil {
+		t.Error("seek() past the end succeeded")
+	}
+}
+
+func Test_replayer_stud(t *testing.T) {
+	hands, err := readPokerStars(strings.NewReader(starsHands))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := newReplayer(hands[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r.frame().street != FourthStreet {
+		r.forward()
+	}
+	if got := formatCards(r.visible(1)[0]); got != "4d 2c" {
+		t.Errorf("on 4th street s2 sees s1's %s, want 4d 2c", got)
+	}
+	if got := formatCards(r.visible(0)[0]); got != "9s 9c 4d 2c" {
+		t.Errorf("on 4th street s1 sees its own %s, want 9s 9c 4d 2c", got)
+	}
+	if got := formatCards(r.visible(0)[1]); got != "Kh 7d" {
+		t.Errorf("on 4th street s1 sees s2's %s, want Kh 7d", got)
+	}
+	if err := r.verify(); err == nil {
+		t.Error("verify() of an imported stud hand succeeded")
+	}
+}
+
+func Test_replayer_verify(t *testing.T) {
+	table := holdemTable(t, 100, 100, 100)
+	var log strings.Builder
+	if err := table.playHands(3, showdownEngine{rng: rand.New(rand.NewSource(9)), log: newHistoryLog(&log)}); err != nil {
+		t.Fatal(err)
+	}
+	fiveCard, err := readHistories(strings.NewReader(log.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	holdem, _ := recordHoldem(t, 12, 1)
+
+	tests := []struct {
+		name   string
+		hands  []*HandHistory
+		tamper func(hh *HandHistory)
+		want   string
+	}{
+		{"five-card", fiveCard, func(*HandHistory) {}, ""},
+		{"holdem", holdem, func(*HandHistory) {}, ""},
+		{"five-card seed", fiveCard, func(hh *HandHistory) { hh.Seed++ }, "seed"},
+		{"holdem seed", holdem, func(hh *HandHistory) { hh.Seed++ }, "seed"},
+		{"hole cards", holdem, func(hh *HandHistory) {
+			hh.Seats[0].Cards[0], hh.Seats[1].Cards[0] = hh.Seats[1].Cards[0], hh.Seats[0].Cards[0]
+		}, "deals seat"},
+		{"awards", holdem, func(hh *HandHistory) {
+			hh.Awards = append([]Award(nil), hh.Awards...)
+			hh.Awards[0].Amount--
+		}, "does not replay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, hh := range tt.hands {
+				copied := *hh
+				copied.Seats = append([]SeatRecord(nil), hh.Seats...)
+				for i := range copied.Seats {
+					copied.Seats[i].Cards = append([]Card(nil), hh.Seats[i].Cards...)
+				}
+				tt.tamper(&copied)
+				r, err := newReplayer(&copied)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = r.verify()
+				switch {
+				case tt.want == "" && err != nil:
+					t.Errorf("verify() = %v", err)
+				case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+					t.Errorf("verify() = %v, want an error about %s", err, tt.want)
+				}
+			}
+		})
+	}
+
+	bad := *holdem[0]
+	bad.Actions = append([]Action(nil), bad.Actions...)
+	bad.Actions[0].Amount = 5000
+	if _, err := newReplayer(&bad); err == nil {
+		t.Error("newReplayer() of a hand with a post bigger than the stack succeeded")
+	}
+}
@@ -35,3 +35,125 @@ func (i Suit) String() string {
 	}
 	return suitName[suitIndex[i]:suitIndex[i+1]]
 }
+
+const badugirankName = "OneCardBadugiTwoCardBadugiThreeCardBadugiBadugi"
+
+var badugirankIndex = [...]uint8{0, 13, 26, 41, 47}
+
+func (i BadugiRank) String() string {
+	i -= 1
+	if i < 0 || i >= BadugiRank(len(badugirankIndex)-1) {
+		return "BadugiRank(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return badugirankName[badugirankIndex[i]:badugirankIndex[i+1]]
+}
+
+const ofcvariantName = "RegularOFCPineappleOFCProgressivePineappleOFC"
+
+var ofcvariantIndex = [...]uint8{0, 10, 22, 45}
+
+func (i OFCVariant) String() string {
+	if i < 0 || i >= OFCVariant(len(ofcvariantIndex)-1) {
+		return "OFCVariant(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return ofcvariantName[ofcvariantIndex[i]:ofcvariantIndex[i+1]]
+}
+
+const ofcrowName = "FrontMiddleBack"
+
+var ofcrowIndex = [...]uint8{0, 5, 11, 15}
+
+func (i OFCRow) String() string {
+	if i < 0 || i >= OFCRow(len(ofcrowIndex)-1) {
+		return "OFCRow(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return ofcrowName[ofcrowIndex[i]:ofcrowIndex[i+1]]
+}
+
+const threecardrankName = "ThreeCardHighThreeCardPairThreeCardFlushThreeCardStraightThreeCardTripsThreeCardStraightFlush"
+
+var threecardrankIndex = [...]uint8{0, 13, 26, 40, 57, 71, 93}
+
+func (i ThreeCardRank) String() string {
+	if i < 0 || i >= ThreeCardRank(len(threecardrankIndex)-1) {
+		return "ThreeCardRank(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return threecardrankName[threecardrankIndex[i]:threecardrankIndex[i+1]]
+}
+
+const drawkindName = "FlushDrawOpenEndedGutshotBackdoorFlushBackdoorStraightOvercards"
+
+var drawkindIndex = [...]uint8{0, 9, 18, 25, 38, 54, 63}
+
+func (i DrawKind) String() string {
+	if i < 0 || i >= DrawKind(len(drawkindIndex)-1) {
+		return "DrawKind(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return drawkindName[drawkindIndex[i]:drawkindIndex[i+1]]
+}
+
+const suittextureName = "RainbowTwoToneThreeFlushFourFlushMonotone"
+
+var suittextureIndex = [...]uint8{0, 7, 14, 24, 33, 41}
+
+func (i SuitTexture) String() string {
+	if i < 0 || i >= SuitTexture(len(suittextureIndex)-1) {
+		return "SuitTexture(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return suittextureName[suittextureIndex[i]:suittextureIndex[i+1]]
+}
+
+const boardpairingName = "UnpairedPairedTwoPairedTripsBoardFullHouseBoardQuadsBoard"
+
+var boardpairingIndex = [...]uint8{0, 8, 14, 23, 33, 47, 57}
+
+func (i BoardPairing) String() string {
+	if i < 0 || i >= BoardPairing(len(boardpairingIndex)-1) {
+		return "BoardPairing(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return boardpairingName[boardpairingIndex[i]:boardpairingIndex[i+1]]
+}
+
+const highcardclassName = "LowBoardMiddleBoardBroadwayBoardAceHigh"
+
+var highcardclassIndex = [...]uint8{0, 8, 19, 32, 39}
+
+func (i HighCardClass) String() string {
+	if i < 0 || i >= HighCardClass(len(highcardclassIndex)-1) {
+		return "HighCardClass(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return highcardclassName[highcardclassIndex[i]:highcardclassIndex[i+1]]
+}
+
+const languageName = "EnglishGermanRussianSpanish"
+
+var languageIndex = [...]uint8{0, 7, 13, 20, 27}
+
+func (i Language) String() string {
+	if i < 0 || i >= Language(len(languageIndex)-1) {
+		return "Language(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return languageName[languageIndex[i]:languageIndex[i+1]]
+}
+
+const streetName = "PreflopFlopTurnRiverThirdStreetFourthStreetFifthStreetSixthStreetSeventhStreet"
+
+var streetIndex = [...]uint8{0, 7, 11, 15, 20, 31, 43, 54, 65, 78}
+
+func (i Street) String() string {
+	if i < 0 || i >= Street(len(streetIndex)-1) {
+		return "Street(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return streetName[streetIndex[i]:streetIndex[i+1]]
+}
+
+const actionkindName = "PostAntePostSmallBlindPostBigBlindPostDeadPostBringInFoldCheckCallBetRaise"
+
+var actionkindIndex = [...]uint8{0, 8, 22, 34, 42, 53, 57, 62, 66, 69, 74}
+
+func (i ActionKind) String() string {
+	if i < 0 || i >= ActionKind(len(actionkindIndex)-1) {
+		return "ActionKind(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return actionkindName[actionkindIndex[i]:actionkindIndex[i+1]]
+}
@@ -0,0 +1,304 @@
+package poker
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"math"
+	"strings"
+)
+
+type shapeKind int
+
+const (
+	rectShape shapeKind = iota
+	ellipseShape
+	textShape
+)
+
+// shape is one thing drawn on a scene. Rectangles and ellipses are given by
+// their bounds, text by the point its left edge or middle is anchored to
+// halfway up the letters.
+type shape struct {
+	kind   shapeKind
+	x, y   int
+	w, h   int
+	radius int
+	fill   color.RGBA
+	stroke color.RGBA
+	text   string
+	size   int
+	middle bool
+}
+
+// scene lays out cards and tables once so that the SVG and PNG writers draw
+// the same picture.
+type scene struct {
+	width, height int
+	background    color.RGBA
+	shapes        []shape
+	fourColor     bool
+}
+
+var (
+	white     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	cardEdge  = color.RGBA{0x55, 0x55, 0x55, 0xff}
+	cardBack  = color.RGBA{0x15, 0x65, 0xc0, 0xff}
+	felt      = color.RGBA{0x2e, 0x7d, 0x32, 0xff}
+	feltEdge  = color.RGBA{0x4e, 0x34, 0x2e, 0xff}
+	seatColor = color.RGBA{0x26, 0x32, 0x38, 0xff}
+	tableBg   = color.RGBA{0x1b, 0x1b, 0x1b, 0xff}
+	chipColor = color.RGBA{0xff, 0xd5, 0x4f, 0xff}
+)
+
+// suitColor returns the color a suit is printed in: red and black, or with
+// a four-color deck blue diamonds and green clubs.
+func suitColor(s Suit, fourColor bool) color.RGBA {
+	switch {
+	case s == Heart, s == Diamond && !fourColor:
+		return color.RGBA{0xc6, 0x28, 0x28, 0xff}
+	case s == Diamond:
+		return color.RGBA{0x15, 0x65, 0xc0, 0xff}
+	case s == Club && fourColor:
+		return color.RGBA{0x2e, 0x7d, 0x32, 0xff}
+	}
+	return color.RGBA{0x21, 0x21, 0x21, 0xff}
+}
+
+func (s *scene) rect(x, y, w, h, radius int, fill, stroke color.RGBA) {
+	s.shapes = append(s.shapes, shape{kind: rectShape, x: x, y: y, w: w, h: h, radius: radius, fill: fill, stroke: stroke})
+}
+
+func (s *scene) ellipse(x, y, w, h int, fill, stroke color.RGBA) {
+	s.shapes = append(s.shapes, shape{kind: ellipseShape, x: x, y: y, w: w, h: h, fill: fill, stroke: stroke})
+}
+
+func (s *scene) text(x, y, size int, middle bool, fill color.RGBA, text string) {
+	s.shapes = append(s.shapes, shape{kind: textShape, x: x, y: y, size: size, middle: middle, fill: fill, text: text})
+}
+
+// card draws a card face up with its top left corner at x, y. Cards are
+// five wide by seven high.
+func (s *scene) card(c Card, x, y, w int) {
+	h := w * 7 / 5
+	s.rect(x, y, w, h, w/10, white, cardEdge)
+	if c == joker {
+		ink := suitColor(Spade, s.fourColor)
+		s.text(x+w/10, y+w/4, w/3, false, ink, jokerText)
+		s.text(x+w/2, y+h*3/5, w/2, true, ink, "★")
+		return
+	}
+	ink := suitColor(c.suit, s.fourColor)
+	s.text(x+w/10, y+w/4, w/3, false, ink, string(rankChar(c.rank)))
+	s.text(x+w/2, y+h*3/5, w/2, true, ink, suitSymbols[c.suit])
+}
+
+// back draws a card face down.
+func (s *scene) back(x, y, w int) {
+	h := w * 7 / 5
+	s.rect(x, y, w, h, w/10, cardBack, white)
+}
+
+// row draws cards side by side with a gap of a tenth of a card between
+// them, returning how wide the row is.
+func (s *scene) row(cards []Card, x, y, w int) int {
+	for i, c := range cards {
+		s.card(c, x+i*(w+w/10), y, w)
+	}
+	return rowWidth(len(cards), w)
+}
+
+func rowWidth(n, w int) int {
+	if n == 0 {
+		return 0
+	}
+	return n*w + (n-1)*(w/10)
+}
+
+// cardsScene lays out a hand or a board in a row.
+func cardsScene(cards []Card, fourColor bool) *scene {
+	const w, margin = 60, 6
+	s := &scene{width: rowWidth(len(cards), w) + 2*margin, height: w*7/5 + 2*margin, fourColor: fourColor}
+	s.row(cards, margin, margin, w)
+	return s
+}
+
+// seatView is what a table picture shows of one seat.
+type seatView struct {
+	name   string
+	stack  int
+	bet    int
+	inHand bool
+	button bool
+	// cards are the hole cards shown face up. Players in the hand whose
+	// cards are not shown get two cards face down.
+	cards []Card
+}
+
+// tableSnapshot is what a table looks like partway through a hand.
+type tableSnapshot struct {
+	// seats has nil for empty seats.
+	seats []*seatView
+	board []Card
+	pots  []int
+}
+
+// snapshot captures the table with the given board and shown hole cards
+// keyed by seat. folded holds the seats that have folded. h may be nil
+// between hands.
+func (t *Table) snapshot(h *TableHand, folded map[int]bool, board []Card, hole map[int][]Card) *tableSnapshot {
+	snap := &tableSnapshot{seats: make([]*seatView, len(t.seats)), board: board}
+	for i, p := range t.seats {
+		if p == nil {
+			continue
+		}
+		snap.seats[i] = &seatView{name: p.name, stack: p.stack, cards: hole[i]}
+	}
+	if h == nil {
+		return snap
+	}
+	// players who left the table mid-hand have no seat to draw but their
+	// chips stay in the pots
+	for _, s := range h.seats {
+		if snap.seats[s] != nil {
+			snap.seats[s].inHand = !folded[s]
+			snap.seats[s].bet = h.bets[s]
+		}
+	}
+	if snap.seats[h.button] != nil {
+		snap.seats[h.button].button = true
+	}
+	// the pot only splits once someone still in is all in
+	live := make([]int, 0, len(h.seats))
+	allIn := false
+	for _, s := range h.seats {
+		if folded[s] {
+			continue
+		}
+		live = append(live, s)
+		if p := t.seats[s]; p != nil {
+			allIn = allIn || p.stack == 0
+		}
+	}
+	if !allIn {
+		snap.pots = []int{h.pot()}
+		return snap
+	}
+	for _, p := range h.sidePots(live) {
+		snap.pots = append(snap.pots, p.amount)
+	}
+
+	return snap
+}
+
+// scene lays the table out as an oval with the board and pots in the middle
+// and the seats around it, the first at the bottom and the rest clockwise.
+func (snap *tableSnapshot) scene(fourColor bool) *scene {
+	const width, height = 900, 600
+	const cx, cy = width / 2, height / 2
+	const rx, ry = 280, 150
+	s := &scene{width: width, height: height, background: tableBg, fourColor: fourColor}
+	s.ellipse(cx-rx-12, cy-ry-12, 2*(rx+12), 2*(ry+12), feltEdge, feltEdge)
+	s.ellipse(cx-rx, cy-ry, 2*rx, 2*ry, felt, felt)
+
+	const boardW = 50
+	s.row(snap.board, cx-rowWidth(5, boardW)/2, cy-boardW*7/5+10, boardW)
+	pots := make([]string, len(snap.pots))
+	for i, p := range snap.pots {
+		pots[i] = fmt.Sprintf("Pot %d", p)
+		if i > 0 {
+			pots[i] = fmt.Sprintf("Side pot %d", p)
+		}
+	}
+	s.text(cx, cy+40, 16, true, white, strings.Join(pots, "  "))
+
+	n := len(snap.seats)
+	for i, seat := range snap.seats {
+		a := math.Pi/2 + 2*math.Pi*float64(i)/float64(n)
+		x := cx + int(math.Round((rx+80)*math.Cos(a)))
+		y := cy + int(math.Round((ry+70)*math.Sin(a)))
+		if seat == nil {
+			s.rect(x-60, y-45, 120, 90, 8, tableBg, seatColor)
+			continue
+		}
+		s.seat(seat, x, y)
+		if seat.bet > 0 {
+			bx := cx + int(math.Round((rx-60)*math.Cos(a)))
+			by := cy + int(math.Round((ry-40)*math.Sin(a)))
+			s.text(bx, by, 14, true, chipColor, fmt.Sprint(seat.bet))
+		}
+		if seat.button {
+			dx := cx + int(math.Round((rx-20)*math.Cos(a+0.3)))
+			dy := cy + int(math.Round((ry-15)*math.Sin(a+0.3)))
+			s.ellipse(dx-11, dy-11, 22, 22, white, cardEdge)
+			s.text(dx, dy, 14, true, seatColor, "D")
+		}
+	}
+
+	return s
+}
+
+// seat draws a seat centered on x, y: the name above the cards and the
+// stack below.
+func (s *scene) seat(seat *seatView, x, y int) {
+	s.rect(x-60, y-45, 120, 90, 8, seatColor, seatColor)
+	s.text(x, y-33, 12, true, white, seat.name)
+	const w = 30
+	switch {
+	case len(seat.cards) > 0:
+		s.row(seat.cards, x-rowWidth(len(seat.cards), w)/2, y-21, w)
+	case seat.inHand:
+		for i := 0; i < 2; i++ {
+			s.back(x-rowWidth(2, w)/2+i*(w+w/10), y-21, w)
+		}
+	}
+	s.text(x, y+33, 12, true, chipColor, fmt.Sprint(seat.stack))
+}
+
+func svgColor(c color.RGBA) string {
+	if c.A == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// svg writes the scene as an SVG document.
+func (s *scene) svg() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		s.width, s.height, s.width, s.height)
+	if s.background.A != 0 {
+		fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", s.width, s.height, svgColor(s.background))
+	}
+	for _, sh := range s.shapes {
+		switch sh.kind {
+		case rectShape:
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s" stroke="%s"/>`+"\n",
+				sh.x, sh.y, sh.w, sh.h, sh.radius, svgColor(sh.fill), svgColor(sh.stroke))
+		case ellipseShape:
+			fmt.Fprintf(&b, `<ellipse cx="%d" cy="%d" rx="%d" ry="%d" fill="%s" stroke="%s"/>`+"\n",
+				sh.x+sh.w/2, sh.y+sh.h/2, sh.w/2, sh.h/2, svgColor(sh.fill), svgColor(sh.stroke))
+		case textShape:
+			anchor := "start"
+			if sh.middle {
+				anchor = "middle"
+			}
+			fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" font-weight="bold" `+
+				`text-anchor="%s" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+				sh.x, sh.y, sh.size, anchor, svgColor(sh.fill), html.EscapeString(sh.text))
+		}
+	}
+	b.WriteString("</svg>\n")
+
+	return b.String()
+}
+
+// cardsSVG draws a hand or a board as SVG.
+func cardsSVG(cards []Card, fourColor bool) string {
+	return cardsScene(cards, fourColor).svg()
+}
+
+// svg draws the table as SVG.
+func (snap *tableSnapshot) svg(fourColor bool) string {
+	return snap.scene(fourColor).svg()
+}
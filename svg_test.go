@@ -0,0 +1,168 @@
+package poker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+// wellFormed reports whether doc parses as XML.
+func wellFormed(t *testing.T, doc string) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("SVG is not well formed: %v", err)
+		}
+	}
+}
+
+func testSnapshot(t *testing.T) *tableSnapshot {
+	t.Helper()
+	table := newTable(6, 1, 2, 0, DeadButton)
+	for i, name := range []string{"Alice", "Bob", "Carol & Dave"} {
+		if err := table.sit(i, &Player{name: name, stack: 200}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h, err := table.startHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := []Card{{Ace, Heart}, {Seven, Club}, {Two, Diamond}}
+	return table.snapshot(h, nil, board, map[int][]Card{0: {{Ace, Spade}, {Ace, Club}}})
+}
+
+func TestTable_snapshot_folded(t *testing.T) {
+	table := newTable(6, 1, 2, 0, DeadButton)
+	for i, stack := range []int{200, 50, 200, 200} {
+		if err := table.sit(i, &Player{name: string(rune('A' + i)), stack: stack}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h, err := table.startHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// seat 1 is all in, seat 3 folds and the others call
+	for s, paid := range map[int]int{0: 100, 1: 50, 2: 100, 3: 10} {
+		h.put(table, s, paid-h.paid[s], true)
+	}
+	folded := map[int]bool{3: true}
+
+	snap := table.snapshot(h, folded, nil, nil)
+	if got := snap.pots; len(got) != 2 || got[0] != 160 || got[1] != 100 {
+		t.Errorf("pots = %v, want [160 100]", got)
+	}
+	if snap.seats[3].inHand || !snap.seats[1].inHand {
+		t.Errorf("in hand = %v %v, want seat 1 in and seat 3 out", snap.seats[1].inHand, snap.seats[3].inHand)
+	}
+
+	// a player who leaves mid-hand is not drawn but their chips stay in
+	table.stand(2)
+	snap = table.snapshot(h, folded, nil, nil)
+	if snap.seats[2] != nil {
+		t.Errorf("seat 2 = %+v, want nil", snap.seats[2])
+	}
+	if got := snap.pots; len(got) != 2 || got[0] != 160 || got[1] != 100 {
+		t.Errorf("pots after seat 2 leaves = %v, want [160 100]", got)
+	}
+	wellFormed(t, snap.svg(false))
+}
+
+func Test_cardsSVG(t *testing.T) {
+	cards := []Card{{Ace, Spade}, {King, Diamond}, {Ten, Club}, {Queen, Heart}}
+	doc := cardsSVG(cards, true)
+	wellFormed(t, doc)
+	for _, want := range []string{
+		`width="270" height="96"`,
+		`fill="#212121">A</text>`,
+		`fill="#1565c0">♦</text>`,
+		`fill="#2e7d32">♣</text>`,
+		`fill="#c62828">♥</text>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("cardsSVG() is missing %s", want)
+		}
+	}
+	if got := strings.Count(doc, "<rect"); got != len(cards) {
+		t.Errorf("cardsSVG() draws %d cards, want %d", got, len(cards))
+	}
+}
+
+func TestTable_snapshot(t *testing.T) {
+	snap := testSnapshot(t)
+	if got := len(snap.seats); got != 6 {
+		t.Fatalf("snapshot has %d seats, want 6", got)
+	}
+	if snap.seats[3] != nil {
+		t.Errorf("empty seat 3 = %+v, want nil", snap.seats[3])
+	}
+	if got := snap.pots; len(got) != 1 || got[0] != 3 {
+		t.Errorf("pots = %v, want [3]", got)
+	}
+	doc := snap.svg(false)
+	wellFormed(t, doc)
+	for _, want := range []string{"Carol &amp; Dave", "Pot 3", ">D</text>"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("svg() is missing %s", want)
+		}
+	}
+}
+
+func Test_png(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cardsPNG(&buf, []Card{{Ace, Heart}, {King, Heart}}, false); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got.X != 138 || got.Y != 96 {
+		t.Errorf("cardsPNG() size = %v, want 138x96", got)
+	}
+	// the middle of the first card's heart
+	if r, g, b, _ := img.At(36, 62).RGBA(); r>>8 != 0xc6 || g>>8 != 0x28 || b>>8 != 0x28 {
+		t.Errorf("heart pixel = %x %x %x, want c6 28 28", r>>8, g>>8, b>>8)
+	}
+	// the corner outside the rounded card is left clear
+	if _, _, _, a := img.At(6, 6).RGBA(); a != 0 {
+		t.Errorf("corner pixel alpha = %x, want 0", a)
+	}
+
+	buf.Reset()
+	if err := testSnapshot(t).png(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_jokerImages(t *testing.T) {
+	cards := []Card{{Ace, Spade}, joker}
+	if doc := cardsSVG(cards, false); !strings.Contains(doc, ">Jk</text>") || !strings.Contains(doc, ">★</text>") {
+		t.Errorf("cardsSVG() draws no joker:\n%s", doc)
+	}
+
+	var buf bytes.Buffer
+	if err := cardsPNG(&buf, cards, false); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the middle of the second card's star
+	if r, g, b, _ := img.At(102, 52).RGBA(); r>>8 != 0x21 || g>>8 != 0x21 || b>>8 != 0x21 {
+		t.Errorf("star pixel = %x %x %x, want 21 21 21", r>>8, g>>8, b>>8)
+	}
+}
@@ -0,0 +1,478 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Player is someone with a stack of chips who can take a seat.
+type Player struct {
+	name       string
+	stack      int
+	sittingOut bool
+	// missedSmall and missedBig are blinds owed from hands sat out or
+	// skipped. A player who just sat down owes a big blind to be dealt in
+	// before the big blind reaches them.
+	missedSmall bool
+	missedBig   bool
+}
+
+type ButtonRule int
+
+const (
+	// DeadButton moves the big blind one player each hand. The small blind
+	// and the button follow where the big blind and the small blind were,
+	// so either can be dead on a seat that emptied.
+	DeadButton ButtonRule = iota
+	// MovingButton moves the button to the next player each hand and posts
+	// the blinds behind it.
+	MovingButton
+)
+
+// Table is a fixed number of seats with a dealer button and blinds.
+type Table struct {
+	seats []*Player
+	rule  ButtonRule
+	small int
+	big   int
+	ante  int
+	// tournament tables deal in players who sit down without charging them
+	// missed blinds. They still wait out a hand sitting between the button
+	// and the big blind.
+	tournament bool
+	// lang is the language prompts and hand names are shown in.
+	lang Language
+
+	hands  int
+	button int
+	// lastSmall and lastBig are the seats the blinds were due on last hand,
+	// whether or not anyone posted them, used by the dead button rule.
+	lastSmall int
+	lastBig   int
+}
+
+// TableHand is one hand at a table once the blinds and antes are in.
+type TableHand struct {
+	number int
+	button int
+	// small is the seat that posted the small blind, or -1 if it is dead.
+	small int
+	big   int
+	// seats are the players dealt in, starting left of the button.
+	seats []int
+	// bets are the live chips each seat has in front of it. Antes and missed
+	// small blinds are dead: they go in the pot but do not count toward a
+	// call.
+	bets map[int]int
+	// paid is everything each seat has put in the pot.
+	paid map[int]int
+	// posts are the antes and blinds as they went in.
+	posts []Action
+}
+
+func newTable(seats int, small, big, ante int, rule ButtonRule) *Table {
+	return &Table{
+		seats:     make([]*Player, seats),
+		rule:      rule,
+		small:     small,
+		big:       big,
+		ante:      ante,
+		button:    -1,
+		lastSmall: -1,
+		lastBig:   -1,
+	}
+}
+
+func (t *Table) sit(seat int, p *Player) error {
+	if seat < 0 || seat >= len(t.seats) {
+		return fmt.Errorf("no seat %d", seat)
+	}
+	if t.seats[seat] != nil {
+		return fmt.Errorf("seat %d is taken", seat)
+	}
+	if t.hands > 0 {
+		p.missedBig = true
+	}
+	t.seats[seat] = p
+
+	return nil
+}
+
+func (t *Table) stand(seat int) *Player {
+	p := t.seats[seat]
+	t.seats[seat] = nil
+	return p
+}
+
+func (t *Table) sitOut(seat int) {
+	if p := t.seats[seat]; p != nil {
+		p.sittingOut = true
+	}
+}
+
+func (t *Table) sitIn(seat int) {
+	if p := t.seats[seat]; p != nil {
+		p.sittingOut = false
+	}
+}
+
+// seated returns how many seats are taken.
+func (t *Table) seated() int {
+	n := 0
+	for _, p := range t.seats {
+		if p != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// openSeat returns the empty seat the big blind reaches first, or -1 if the
+// table is full.
+func (t *Table) openSeat() int {
+	for i := 1; i <= len(t.seats); i++ {
+		s := (t.lastBig + i + len(t.seats)) % len(t.seats)
+		if t.seats[s] == nil {
+			return s
+		}
+	}
+	return -1
+}
+
+// active reports whether the seat can be dealt in.
+func (t *Table) active(seat int) bool {
+	p := t.seats[seat]
+	return p != nil && !p.sittingOut && p.stack > 0
+}
+
+// players returns how many seats can be dealt in.
+func (t *Table) players() int {
+	n := 0
+	for s := range t.seats {
+		if t.active(s) {
+			n++
+		}
+	}
+	return n
+}
+
+// next returns the first active seat after seat, or -1.
+func (t *Table) next(seat int) int {
+	for i := 1; i <= len(t.seats); i++ {
+		s := (seat + i + len(t.seats)) % len(t.seats)
+		if t.active(s) {
+			return s
+		}
+	}
+	return -1
+}
+
+// between calls f for every seat strictly after from and before to.
+func (t *Table) between(from, to int, f func(seat int)) {
+	for s := (from + 1) % len(t.seats); s != to; s = (s + 1) % len(t.seats) {
+		f(s)
+	}
+}
+
+// positions moves the button and works out where the blinds are due for the
+// next hand. Sitting-out players the big blind passes owe what they skipped.
+func (t *Table) positions() (button, small, big int) {
+	owe := func(from, to int) {
+		t.between(from, to, func(s int) {
+			if p := t.seats[s]; p != nil && p.stack > 0 && p.sittingOut {
+				p.missedBig = true
+				p.missedSmall = true
+			}
+		})
+	}
+
+	switch {
+	case t.lastBig < 0 && t.players() == 2:
+		button = t.next(-1)
+		return button, button, t.next(button)
+	case t.lastBig < 0:
+		button = t.next(-1)
+		small = t.next(button)
+		return button, small, t.next(small)
+	case t.players() == 2:
+		big = t.next(t.lastBig)
+		owe(t.lastBig, big)
+		button = t.next(big)
+		return button, button, big
+	case t.rule == MovingButton:
+		button = t.next(t.button)
+		small = t.next(button)
+		big = t.next(small)
+		owe(t.lastBig, big)
+		return button, small, big
+	}
+
+	big = t.next(t.lastBig)
+	owe(t.lastBig, big)
+	if p := t.seats[t.lastBig]; p != nil && p.sittingOut {
+		p.missedSmall = true
+	}
+
+	return t.lastSmall, t.lastBig, big
+}
+
+// put moves up to amount from the seat's stack into the hand and returns
+// how much it moved.
+func (h *TableHand) put(t *Table, seat, amount int, live bool) int {
+	p := t.seats[seat]
+	if amount > p.stack {
+		amount = p.stack
+	}
+	p.stack -= amount
+	h.paid[seat] += amount
+	if live {
+		h.bets[seat] += amount
+	}
+	return amount
+}
+
+// post puts an ante or a blind in and records it. Blinds and bring-ins are
+// live, antes and dead small blinds are not.
+func (h *TableHand) post(t *Table, seat, amount int, kind ActionKind) {
+	live := kind == PostSmallBlind || kind == PostBigBlind || kind == PostBringIn
+	if n := h.put(t, seat, amount, live); n > 0 {
+		h.posts = append(h.posts, Action{Street: Preflop, Seat: seat, Kind: kind, Amount: n, AllIn: t.seats[seat].stack == 0})
+	}
+}
+
+// startHand moves the button, collects antes and blinds, including blinds
+// owed by returning players, and returns the hand to be played.
+func (t *Table) startHand() (*TableHand, error) {
+	if t.players() < 2 {
+		return nil, fmt.Errorf("not enough players")
+	}
+	button, due, big := t.positions()
+	small := -1
+	if t.active(due) {
+		small = due
+	}
+	t.hands++
+	h := &TableHand{
+		number: t.hands,
+		button: button,
+		small:  small,
+		big:    big,
+		bets:   make(map[int]int),
+		paid:   make(map[int]int),
+	}
+
+	for i := 1; i <= len(t.seats); i++ {
+		s := (button + i) % len(t.seats)
+		if !t.active(s) {
+			continue
+		}
+		p := t.seats[s]
+		if s != big && p.missedBig && t.players() > 2 && seatBetween(button, big, s, len(t.seats)) {
+			// a returning player between the button and the big blind
+			// waits for the big blind rather than posting
+			continue
+		}
+		h.seats = append(h.seats, s)
+	}
+
+	for _, s := range h.seats {
+		h.post(t, s, t.ante, PostAnte)
+	}
+	if small >= 0 {
+		h.post(t, small, t.small, PostSmallBlind)
+	}
+	h.post(t, big, t.big, PostBigBlind)
+	for _, s := range h.seats {
+		p := t.seats[s]
+		if s == big {
+			p.missedBig, p.missedSmall = false, false
+			continue
+		}
+		if p.missedBig && !t.tournament {
+			h.post(t, s, t.big-h.bets[s], PostBigBlind)
+		}
+		if p.missedSmall && s != small && !t.tournament {
+			h.post(t, s, t.small, PostDead)
+		}
+		p.missedBig, p.missedSmall = false, false
+	}
+
+	t.button = button
+	t.lastSmall = due
+	t.lastBig = big
+
+	return h, nil
+}
+
+// seatBetween reports whether seat sits strictly after from and before to
+// going around a table of n seats.
+func seatBetween(from, to, seat, n int) bool {
+	return (seat-from+n)%n < (to-from+n)%n && seat != from
+}
+
+// pot returns all the chips in the hand.
+func (h *TableHand) pot() int {
+	n := 0
+	for _, c := range h.paid {
+		n += c
+	}
+	return n
+}
+
+// sidePot is a pot and the seats that can win it. won holds what each
+// seat took from it once it is paid.
+type sidePot struct {
+	amount int
+	seats  []int
+	won    map[int]int
+}
+
+// sidePots splits the pot into a main pot and side pots by how much each
+// seat still in the hand put in. Chips of seats that folded go to the pots
+// they reached.
+func (h *TableHand) sidePots(live []int) []sidePot {
+	levels := make([]int, 0, len(live))
+	for _, s := range live {
+		levels = append(levels, h.paid[s])
+	}
+	sort.Ints(levels)
+
+	pots := make([]sidePot, 0)
+	prev := 0
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		pot := sidePot{}
+		for _, paid := range h.paid {
+			pot.amount += clamp(paid-prev, 0, level-prev)
+		}
+		for _, s := range live {
+			if h.paid[s] >= level {
+				pot.seats = append(pot.seats, s)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+	for _, paid := range h.paid {
+		if paid > prev && len(pots) > 0 {
+			pots[len(pots)-1].amount += paid - prev
+		}
+	}
+
+	return pots
+}
+
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
+
+// HandEngine plays out a hand once the blinds are in and pays the pots into
+// the winners' stacks.
+type HandEngine interface {
+	play(t *Table, h *TableHand) error
+}
+
+// playHands runs n consecutive hands, stopping early when fewer than two
+// players can be dealt in.
+func (t *Table) playHands(n int, engine HandEngine) error {
+	for i := 0; i < n; i++ {
+		h, err := t.startHand()
+		if err != nil {
+			return err
+		}
+		if err := engine.play(t, h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// award pays every pot to the strongest hands among the seats that can win
+// it and returns the pots with what each seat won from them. Odd chips go to
+// the first winner left of the button.
+func (h *TableHand) award(t *Table, live []int, strength func(seat int) handValue) []sidePot {
+	pots := h.sidePots(live)
+	for i, pot := range pots {
+		var best handValue
+		winners := make([]int, 0)
+		for _, s := range h.seats {
+			if !contains(pot.seats, s) {
+				continue
+			}
+			switch v := strength(s); {
+			case len(winners) == 0 || v > best:
+				best = v
+				winners = append(winners[:0], s)
+			case v == best:
+				winners = append(winners, s)
+			}
+		}
+		share := pot.amount / len(winners)
+		odd := pot.amount % len(winners)
+		pots[i].won = make(map[int]int)
+		for j, s := range winners {
+			n := share
+			if j < odd {
+				n++
+			}
+			t.seats[s].stack += n
+			pots[i].won[s] += n
+		}
+	}
+
+	return pots
+}
+
+func contains(seats []int, seat int) bool {
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// showdownEngine deals every player a five-card hand and shows them down with
+// no betting beyond the blinds and antes. A seeded source makes the deals
+// repeatable. Each hand's history goes to log when there is one.
+type showdownEngine struct {
+	rng *rand.Rand
+	log *historyLog
+}
+
+func (e showdownEngine) play(t *Table, h *TableHand) error {
+	hh, err := playShowdown(t, h, handSeed(e.rng))
+	if err != nil {
+		return err
+	}
+	return e.log.write(hh)
+}
+
+// playShowdown plays a five-card showdown dealt from the given seed.
+func playShowdown(t *Table, h *TableHand, seed int64) (*HandHistory, error) {
+	hh := newHistory(fiveCardGame, t, h, seed)
+	hands, err := dealWith(rand.New(rand.NewSource(seed)), len(h.seats))
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[int]handValue)
+	for i, s := range h.seats {
+		hh.seat(s).Cards = append([]Card(nil), hands[i].cards[:]...)
+		hands[i].score()
+		values[s] = valueOf(&hands[i])
+		hh.Showdown = append(hh.Showdown, ShowdownRecord{Seat: s, Category: hands[i].handRank, Description: hands[i].describe()})
+	}
+	hh.award(h, h.award(t, h.seats, func(s int) handValue { return values[s] }))
+
+	return hh, nil
+}
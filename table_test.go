@@ -0,0 +1,167 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func seatedTable(t *testing.T, rule ButtonRule, stacks ...int) *Table {
+	t.Helper()
+	table := newTable(len(stacks), 5, 10, 0, rule)
+	for i, s := range stacks {
+		if err := table.sit(i, &Player{name: string(rune('A' + i)), stack: s}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return table
+}
+
+func TestTable_sit(t *testing.T) {
+	table := newTable(2, 5, 10, 0, DeadButton)
+	if err := table.sit(2, &Player{}); err == nil {
+		t.Errorf("sit() error = nil, want no seat")
+	}
+	if err := table.sit(0, &Player{}); err != nil {
+		t.Errorf("sit() error = %v", err)
+	}
+	if err := table.sit(0, &Player{}); err == nil {
+		t.Errorf("sit() error = nil, want seat taken")
+	}
+	if _, err := table.startHand(); err == nil {
+		t.Errorf("startHand() error = nil, want not enough players")
+	}
+}
+
+func TestTable_startHand(t *testing.T) {
+	type positions struct{ button, small, big int }
+	tests := []struct {
+		name   string
+		rule   ButtonRule
+		before map[int]func(t *Table)
+		want   []positions
+	}{
+		{
+			name: "blinds move one seat a hand",
+			rule: DeadButton,
+			want: []positions{{0, 1, 2}, {1, 2, 3}, {2, 3, 0}, {3, 0, 1}, {0, 1, 2}},
+		},
+		{
+			name: "big blind leaves so the small blind is dead",
+			rule: DeadButton,
+			before: map[int]func(t *Table){
+				2: func(t *Table) { t.stand(3) },
+			},
+			want: []positions{{0, 1, 2}, {1, 2, 3}, {2, -1, 0}, {3, 0, 1}},
+		},
+		{
+			name: "small blind leaves so the button is dead",
+			rule: DeadButton,
+			before: map[int]func(t *Table){
+				2: func(t *Table) { t.stand(2) },
+			},
+			want: []positions{{0, 1, 2}, {1, 2, 3}, {2, 3, 0}, {3, 0, 1}},
+		},
+		{
+			name: "moving button skips the empty seat",
+			rule: MovingButton,
+			before: map[int]func(t *Table){
+				2: func(t *Table) { t.stand(3) },
+			},
+			want: []positions{{0, 1, 2}, {1, 2, 3}, {2, 0, 1}, {0, 1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := seatedTable(t, tt.rule, 100, 100, 100, 100)
+			for i, want := range tt.want {
+				if f := tt.before[i]; f != nil {
+					f(table)
+				}
+				h, err := table.startHand()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := (positions{h.button, h.small, h.big}); got != want {
+					t.Errorf("hand %d = %+v, want %+v", i+1, got, want)
+				}
+				for s, p := range table.seats {
+					if p != nil {
+						p.stack += h.paid[s]
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTable_headsUp(t *testing.T) {
+	table := seatedTable(t, DeadButton, 100, 100)
+	for i := 0; i < 3; i++ {
+		h, err := table.startHand()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.small != h.button || h.big == h.button {
+			t.Errorf("hand %d: button %d small %d big %d, want the button in the small blind", i+1, h.button, h.small, h.big)
+		}
+	}
+}
+
+func TestTable_missedBlinds(t *testing.T) {
+	table := seatedTable(t, DeadButton, 100, 100, 100, 100)
+	table.startHand()
+	table.sitOut(3)
+	h, _ := table.startHand()
+	if h.big != 0 {
+		t.Fatalf("big blind = %d, want the sitting-out seat skipped", h.big)
+	}
+	p := table.seats[3]
+	if !p.missedBig || !p.missedSmall {
+		t.Fatalf("missed blinds = %v %v, want both owed", p.missedBig, p.missedSmall)
+	}
+
+	table.sitIn(3)
+	h, _ = table.startHand()
+	if contains(h.seats, 3) {
+		t.Errorf("seat 3 dealt in between the button and the blinds")
+	}
+	h, _ = table.startHand()
+	if !contains(h.seats, 3) {
+		t.Fatalf("seat 3 not dealt in")
+	}
+	if h.bets[3] != 10 || h.paid[3] != 15 {
+		t.Errorf("seat 3 posted %d live, %d in all, want 10 and 15", h.bets[3], h.paid[3])
+	}
+	if p.missedBig || p.missedSmall {
+		t.Errorf("missed blinds still owed after posting")
+	}
+}
+
+func TestTableHand_sidePots(t *testing.T) {
+	h := TableHand{paid: map[int]int{0: 100, 1: 50, 2: 100, 3: 20}}
+	want := []sidePot{
+		{amount: 170, seats: []int{0, 1, 2}},
+		{amount: 100, seats: []int{0, 2}},
+	}
+	if got := h.sidePots([]int{0, 1, 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("sidePots() = %v, want %v", got, want)
+	}
+}
+
+func TestTable_playHands(t *testing.T) {
+	table := seatedTable(t, DeadButton, 100, 100, 100, 100, 100, 100)
+	table.ante = 1
+	if err := table.playHands(50, showdownEngine{}); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, p := range table.seats {
+		total += p.stack
+	}
+	if total != 600 {
+		t.Errorf("chips = %d after 50 hands, want 600", total)
+	}
+	if table.hands != 50 {
+		t.Errorf("hands = %d, want 50", table.hands)
+	}
+}
@@ -0,0 +1,197 @@
+package poker
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// Texture describes a flop, turn or river board.
+type Texture struct {
+	pairing BoardPairing
+	suits   SuitTexture
+	high    HighCardClass
+	// top is the highest rank on the board, broadway counts the tens and
+	// above and low the eights and below.
+	top      Rank
+	broadway int
+	low      int
+	// connected is the most board ranks that fit in one straight.
+	connected int
+	// straights are the top ranks of the straights two hole cards can make,
+	// and oneCard those one hole card makes.
+	straights []Rank
+	oneCard   []Rank
+	// flushes are the suits a player can make a flush in and flushDraws the
+	// suits with two cards and more cards to come.
+	flushes    []Suit
+	flushDraws []Suit
+}
+
+// straightMask is the ranks of the straight with the given top rank; the
+// wheel tops out at five and uses the ace.
+func straightMask(top Rank) uint16 {
+	if top == Five {
+		return 1<<12 | 0xf
+	}
+	return 0x1f << (top - 6)
+}
+
+// texture classifies a board of three to five cards.
+func texture(board []Card) Texture {
+	var t Texture
+	m := masksOf(board)
+	ranks := m[0] | m[1] | m[2] | m[3]
+
+	count := make(map[Rank]int)
+	for _, c := range board {
+		count[c.rank]++
+		if c.rank > t.top {
+			t.top = c.rank
+		}
+		if c.rank >= Ten {
+			t.broadway++
+		}
+		if c.rank <= Eight {
+			t.low++
+		}
+	}
+	pairs, trips := 0, 0
+	for _, n := range count {
+		switch n {
+		case 2:
+			pairs++
+		case 3:
+			trips++
+		case 4:
+			t.pairing = QuadsBoard
+		}
+	}
+	switch {
+	case t.pairing == QuadsBoard:
+	case trips > 0 && pairs > 0:
+		t.pairing = FullHouseBoard
+	case trips > 0:
+		t.pairing = TripsBoard
+	case pairs > 1:
+		t.pairing = TwoPaired
+	case pairs == 1:
+		t.pairing = Paired
+	}
+
+	most := 0
+	for s, mask := range m {
+		n := bits.OnesCount16(mask)
+		if n > most {
+			most = n
+		}
+		switch {
+		case n >= 3:
+			t.flushes = append(t.flushes, Suit(s))
+		case n == 2 && len(board) < 5:
+			t.flushDraws = append(t.flushDraws, Suit(s))
+		}
+	}
+	switch {
+	case most == len(board):
+		t.suits = Monotone
+	case most == 4:
+		t.suits = FourFlush
+	case most == 3:
+		t.suits = ThreeFlush
+	case most == 2:
+		t.suits = TwoTone
+	}
+
+	switch {
+	case t.top == Ace:
+		t.high = AceHigh
+	case t.top >= Ten:
+		t.high = BroadwayBoard
+	case t.top >= Eight:
+		t.high = MiddleBoard
+	}
+
+	for top := Five; top <= Ace; top++ {
+		s := straightMask(top)
+		on := bits.OnesCount16(ranks & s)
+		if on > t.connected {
+			t.connected = on
+		}
+		switch {
+		case on >= 4:
+			t.oneCard = append(t.oneCard, top)
+		case on == 3:
+			t.straights = append(t.straights, top)
+		}
+	}
+
+	return t
+}
+
+// textureKey is the part of a texture flops are clustered by.
+type textureKey struct {
+	pairing BoardPairing
+	suits   SuitTexture
+	high    HighCardClass
+	// connected is 3 when one five-rank window holds the whole flop, so two
+	// hole cards make a straight.
+	connected int
+}
+
+func (t Texture) key() textureKey {
+	return textureKey{pairing: t.pairing, suits: t.suits, high: t.high, connected: t.connected}
+}
+
+func (k textureKey) String() string {
+	return fmt.Sprintf("%s %s %s connected %d", k.high, k.pairing, k.suits, k.connected)
+}
+
+// boardFlopIndexer numbers flops up to a change of suits: the 1,755
+// strategically distinct flops.
+var boardFlopIndexer = newHandIndexer(3)
+
+// flopCluster is a set of distinct flops with the same texture key, and how
+// many of the 22,100 flops they stand for.
+type flopCluster struct {
+	key    textureKey
+	flops  [][3]Card
+	weight int
+}
+
+// clusterFlops groups the distinct flops by texture, the most common
+// clusters first.
+func clusterFlops() []flopCluster {
+	weights := make([]int, boardFlopIndexer.size())
+	cards := deck()
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			for k := j + 1; k < len(cards); k++ {
+				weights[boardFlopIndexer.index([]Card{cards[i], cards[j], cards[k]})]++
+			}
+		}
+	}
+
+	byKey := make(map[textureKey]*flopCluster)
+	keys := make([]textureKey, 0)
+	for i, w := range weights {
+		flop := [3]Card(boardFlopIndexer.unindex(int64(i)))
+		k := texture(flop[:]).key()
+		c, ok := byKey[k]
+		if !ok {
+			c = &flopCluster{key: k}
+			byKey[k] = c
+			keys = append(keys, k)
+		}
+		c.flops = append(c.flops, flop)
+		c.weight += w
+	}
+
+	clusters := make([]flopCluster, 0, len(keys))
+	for _, k := range keys {
+		clusters = append(clusters, *byKey[k])
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].weight > clusters[j].weight })
+
+	return clusters
+}
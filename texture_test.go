@@ -0,0 +1,103 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_texture(t *testing.T) {
+	tests := []struct {
+		name      string
+		board     []Card
+		pairing   BoardPairing
+		suits     SuitTexture
+		high      HighCardClass
+		connected int
+		straights []Rank
+		oneCard   []Rank
+		flushes   int
+		draws     int
+	}{
+		{
+			name:      "dry rainbow",
+			board:     []Card{{King, Spade}, {Seven, Heart}, {Two, Club}},
+			pairing:   Unpaired,
+			suits:     Rainbow,
+			high:      BroadwayBoard,
+			connected: 1,
+		},
+		{
+			name:      "wet two-tone",
+			board:     []Card{{Jack, Heart}, {Ten, Heart}, {Nine, Club}},
+			suits:     TwoTone,
+			high:      BroadwayBoard,
+			connected: 3,
+			straights: []Rank{Jack, Queen, King},
+			draws:     1,
+		},
+		{
+			name:      "monotone wheel cards",
+			board:     []Card{{Ace, Diamond}, {Four, Diamond}, {Three, Diamond}},
+			suits:     Monotone,
+			high:      AceHigh,
+			connected: 3,
+			straights: []Rank{Five},
+			flushes:   1,
+		},
+		{
+			name:      "paired low",
+			board:     []Card{{Six, Diamond}, {Six, Club}, {Two, Spade}},
+			pairing:   Paired,
+			suits:     Rainbow,
+			connected: 2,
+		},
+		{
+			name:      "four to a straight on the river",
+			board:     []Card{{Eight, Spade}, {Seven, Heart}, {Six, Club}, {Five, Spade}, {Five, Diamond}},
+			pairing:   Paired,
+			suits:     TwoTone,
+			high:      MiddleBoard,
+			connected: 4,
+			straights: []Rank{Seven, Ten},
+			oneCard:   []Rank{Eight, Nine},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := texture(tt.board)
+			if got.pairing != tt.pairing || got.suits != tt.suits || got.high != tt.high || got.connected != tt.connected {
+				t.Errorf("texture() = %s %s %s connected %d, want %s %s %s connected %d",
+					got.pairing, got.suits, got.high, got.connected, tt.pairing, tt.suits, tt.high, tt.connected)
+			}
+			if !reflect.DeepEqual(got.straights, tt.straights) || !reflect.DeepEqual(got.oneCard, tt.oneCard) {
+				t.Errorf("straights = %v and %v, want %v and %v", got.straights, got.oneCard, tt.straights, tt.oneCard)
+			}
+			if len(got.flushes) != tt.flushes || len(got.flushDraws) != tt.draws {
+				t.Errorf("%d flush suits and %d draws, want %d and %d", len(got.flushes), len(got.flushDraws), tt.flushes, tt.draws)
+			}
+		})
+	}
+}
+
+func Test_clusterFlops(t *testing.T) {
+	if n := boardFlopIndexer.size(); n != 1755 {
+		t.Fatalf("%d distinct flops, want 1755", n)
+	}
+	clusters := clusterFlops()
+	flops, weight := 0, 0
+	for i, c := range clusters {
+		if i > 0 && c.weight > clusters[i-1].weight {
+			t.Errorf("cluster %s out of order", c.key)
+		}
+		for _, f := range c.flops {
+			if texture(f[:]).key() != c.key {
+				t.Errorf("flop %v in cluster %s", f, c.key)
+			}
+		}
+		flops += len(c.flops)
+		weight += c.weight
+	}
+	if flops != 1755 || weight != 22100 {
+		t.Errorf("clusters hold %d flops standing for %d, want 1755 and 22100", flops, weight)
+	}
+}
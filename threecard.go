@@ -0,0 +1,126 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ThreeCardHand is a three-card hand as played in Three Card Poker, where a
+// straight beats a flush and trips beat both.
+type ThreeCardHand struct {
+	cards    [3]Card
+	handRank ThreeCardRank
+	ranks    [3]Rank
+}
+
+func (h *ThreeCardHand) sort() {
+	sort.SliceStable(h.cards[:], func(i, j int) bool {
+		return h.cards[i].rank > h.cards[j].rank
+	})
+}
+
+func (h *ThreeCardHand) flush() bool {
+	return h.cards[0].suit == h.cards[1].suit && h.cards[1].suit == h.cards[2].suit
+}
+
+// straight reports a straight and sets its ranks. A-2-3 is the lowest
+// straight and A-K-Q the highest.
+func (h *ThreeCardHand) straight() bool {
+	r := h.cards
+	if r[0].rank == Ace && r[1].rank == Three && r[2].rank == Two {
+		h.ranks = [3]Rank{Three, Two, 1}
+		return true
+	}
+	if r[0].rank != r[1].rank+1 || r[1].rank != r[2].rank+1 {
+		return false
+	}
+	h.ranks = [3]Rank{r[0].rank, r[1].rank, r[2].rank}
+
+	return true
+}
+
+func (h *ThreeCardHand) kind() ThreeCardRank {
+	r := h.cards
+	switch {
+	case r[0].rank == r[2].rank:
+		h.ranks = [3]Rank{r[0].rank}
+		return ThreeCardTrips
+	case r[0].rank == r[1].rank:
+		h.ranks = [3]Rank{r[0].rank, r[2].rank}
+		return ThreeCardPair
+	case r[1].rank == r[2].rank:
+		h.ranks = [3]Rank{r[1].rank, r[0].rank}
+		return ThreeCardPair
+	}
+	h.ranks = [3]Rank{r[0].rank, r[1].rank, r[2].rank}
+
+	return ThreeCardHigh
+}
+
+func (h *ThreeCardHand) score() {
+	h.sort()
+	h.ranks = [3]Rank{}
+	f := h.flush()
+	s := h.straight()
+	switch {
+	case f && s:
+		h.handRank = ThreeCardStraightFlush
+	case s:
+		h.handRank = ThreeCardStraight
+	default:
+		h.handRank = h.kind()
+		if f {
+			h.handRank = ThreeCardFlush
+		}
+	}
+}
+
+// miniRoyal reports a scored A-K-Q straight flush.
+func (h *ThreeCardHand) miniRoyal() bool {
+	return h.handRank == ThreeCardStraightFlush && h.ranks[0] == Ace
+}
+
+// compareThree returns a positive number if a beats b, a negative number if b
+// beats a and zero on a split.
+func compareThree(a, b *ThreeCardHand) int {
+	if a.handRank != b.handRank {
+		return int(a.handRank) - int(b.handRank)
+	}
+	for i, r := range a.ranks {
+		if r != b.ranks[i] {
+			return int(r) - int(b.ranks[i])
+		}
+	}
+
+	return 0
+}
+
+func threeCardPlay(hands []ThreeCardHand) []ThreeCardHand {
+	winners := make([]ThreeCardHand, 0)
+
+	for _, h := range hands {
+		h.score()
+		if len(winners) == 0 {
+			winners = append(winners, h)
+			continue
+		}
+		switch c := compareThree(&h, &winners[0]); {
+		case c > 0:
+			winners = winners[:0]
+			winners = append(winners, h)
+		case c == 0:
+			winners = append(winners, h)
+		}
+	}
+
+	return winners
+}
+
+func (h *ThreeCardHand) String() string {
+	buf := ""
+	for _, c := range h.cards {
+		buf += fmt.Sprintf("%s:%s ", c.rank, c.suit)
+	}
+
+	return buf
+}
@@ -0,0 +1,109 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestThreeCardHand_score(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     [3]Card
+		wantScore ThreeCardRank
+		wantRanks [3]Rank
+	}{
+		{
+			name:      "mini royal",
+			cards:     [3]Card{{Queen, Heart}, {Ace, Heart}, {King, Heart}},
+			wantScore: ThreeCardStraightFlush,
+			wantRanks: [3]Rank{Ace, King, Queen},
+		},
+		{
+			name:      "trips",
+			cards:     [3]Card{{Two, Heart}, {Two, Spade}, {Two, Club}},
+			wantScore: ThreeCardTrips,
+			wantRanks: [3]Rank{Two},
+		},
+		{
+			name:      "ace low straight",
+			cards:     [3]Card{{Two, Heart}, {Ace, Spade}, {Three, Club}},
+			wantScore: ThreeCardStraight,
+			wantRanks: [3]Rank{Three, Two, Rank(1)},
+		},
+		{
+			name:      "flush",
+			cards:     [3]Card{{Two, Diamond}, {Jack, Diamond}, {Nine, Diamond}},
+			wantScore: ThreeCardFlush,
+			wantRanks: [3]Rank{Jack, Nine, Two},
+		},
+		{
+			name:      "pair",
+			cards:     [3]Card{{Nine, Diamond}, {Jack, Club}, {Nine, Heart}},
+			wantScore: ThreeCardPair,
+			wantRanks: [3]Rank{Nine, Jack},
+		},
+		{
+			name:      "high card",
+			cards:     [3]Card{{Queen, Diamond}, {Six, Club}, {Four, Heart}},
+			wantScore: ThreeCardHigh,
+			wantRanks: [3]Rank{Queen, Six, Four},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := ThreeCardHand{
+				cards: tt.cards,
+			}
+			h.score()
+			if got := h.handRank; got != tt.wantScore {
+				t.Errorf("score() = %v, want %v", got, tt.wantScore)
+			}
+			if got := h.ranks; got != tt.wantRanks {
+				t.Errorf("score() = %v, want %v", got, tt.wantRanks)
+			}
+		})
+	}
+}
+
+func TestThreeCardHand_frequencies(t *testing.T) {
+	want := map[ThreeCardRank]int{
+		ThreeCardStraightFlush: 48,
+		ThreeCardTrips:         52,
+		ThreeCardStraight:      720,
+		ThreeCardFlush:         1096,
+		ThreeCardPair:          3744,
+		ThreeCardHigh:          16440,
+	}
+	got := make(map[ThreeCardRank]int)
+	royals := 0
+	cards := deck()
+	for i := 0; i < len(cards); i++ {
+		for j := i + 1; j < len(cards); j++ {
+			for k := j + 1; k < len(cards); k++ {
+				h := ThreeCardHand{cards: [3]Card{cards[i], cards[j], cards[k]}}
+				h.score()
+				got[h.handRank]++
+				if h.miniRoyal() {
+					royals++
+				}
+			}
+		}
+	}
+	for r, n := range want {
+		if got[r] != n {
+			t.Errorf("%s = %d, want %d", r, got[r], n)
+		}
+	}
+	if royals != 4 {
+		t.Errorf("mini royals = %d, want 4", royals)
+	}
+}
+
+func Test_threeCardPlay(t *testing.T) {
+	straight := ThreeCardHand{cards: [3]Card{{Four, Heart}, {Two, Spade}, {Three, Club}}}
+	flush := ThreeCardHand{cards: [3]Card{{Ace, Club}, {King, Club}, {Jack, Club}}}
+	wheel := ThreeCardHand{cards: [3]Card{{Ace, Heart}, {Two, Heart}, {Three, Spade}}}
+	winners := threeCardPlay([]ThreeCardHand{flush, wheel, straight})
+	if len(winners) != 1 || winners[0].ranks[0] != Four {
+		t.Errorf("threeCardPlay() = %v, want four high straight", winners)
+	}
+}
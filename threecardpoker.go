@@ -0,0 +1,120 @@
+package poker
+
+// threeCardPays is a Three Card Poker paytable keyed by category, in units
+// won per unit bet.
+type threeCardPays map[ThreeCardRank]float64
+
+// pairPlus pays on the player's hand alone, win or lose against the dealer.
+var pairPlus = threeCardPays{
+	ThreeCardStraightFlush: 40,
+	ThreeCardTrips:         30,
+	ThreeCardStraight:      6,
+	ThreeCardFlush:         4,
+	ThreeCardPair:          1,
+}
+
+// anteBonus pays on the ante whenever the player plays.
+var anteBonus = threeCardPays{
+	ThreeCardStraightFlush: 5,
+	ThreeCardTrips:         4,
+	ThreeCardStraight:      1,
+}
+
+// threeValue packs a scored hand into one number ordered like compareThree.
+func threeValue(h *ThreeCardHand) int {
+	v := int(h.handRank)
+	for _, r := range h.ranks {
+		v = v<<4 | int(r)
+	}
+	return v
+}
+
+// threeCardQualifier is the lowest dealer hand that qualifies, queen high.
+var threeCardQualifier = threeValue(&ThreeCardHand{ranks: [3]Rank{Queen}})
+
+// threeCardOptimal is Q-6-4, the lowest hand worth a play bet.
+var threeCardOptimal = threeValue(&ThreeCardHand{ranks: [3]Rank{Queen, Six, Four}})
+
+func scoreThreeCards(cards [3]Card) ThreeCardHand {
+	h := ThreeCardHand{cards: cards}
+	h.score()
+	return h
+}
+
+// threeCardPlays reports whether the player should make the play bet.
+func threeCardPlays(h *ThreeCardHand) bool {
+	return threeValue(h) >= threeCardOptimal
+}
+
+// threeCardPokerSettle returns the player's net result for one hand. The
+// play bet equals the ante. If the dealer does not qualify the ante wins even
+// money and the play bet pushes.
+func threeCardPokerSettle(player, dealer [3]Card, ante, pair int, play bool) float64 {
+	p := scoreThreeCards(player)
+	d := scoreThreeCards(dealer)
+
+	net := 0.0
+	if pair > 0 {
+		if pays, ok := pairPlus[p.handRank]; ok {
+			net += float64(pair) * pays
+		} else {
+			net -= float64(pair)
+		}
+	}
+	if !play {
+		return net - float64(ante)
+	}
+	net += float64(ante) * anteBonus[p.handRank]
+
+	switch c := compareThree(&p, &d); {
+	case threeValue(&d) < threeCardQualifier:
+		net += float64(ante)
+	case c > 0:
+		net += 2 * float64(ante)
+	case c < 0:
+		net -= 2 * float64(ante)
+	}
+
+	return net
+}
+
+// threeCardPokerEdge enumerates every player hand against every dealer hand
+// and returns the house edge per unit ante of playing Q-6-4 or better.
+func threeCardPokerEdge() float64 {
+	hands := enumerateHands(3, func(cards []Card) int {
+		h := scoreThreeCards([3]Card(cards))
+		return threeValue(&h)
+	})
+	odds := enumerateDealer(hands, 3, threeCardQualifier)
+
+	var total float64
+	for i, h := range hands {
+		if h.value < threeCardOptimal {
+			total -= float64(odds[i].unqualified + odds[i].below + odds[i].level + odds[i].above)
+			continue
+		}
+		o := odds[i]
+		played := float64(o.unqualified) + 2*float64(o.below) - 2*float64(o.above)
+		n := o.unqualified + o.below + o.level + o.above
+		total += played + float64(n)*anteBonus[ThreeCardRank(h.value>>12)]
+	}
+
+	return -total / float64(binomial[52][3]*binomial[49][3])
+}
+
+// pairPlusEdge returns the house edge of a Pair Plus paytable.
+func pairPlusEdge(pays threeCardPays) float64 {
+	var total float64
+	for _, h := range enumerateHands(3, func(cards []Card) int {
+		h := scoreThreeCards([3]Card(cards))
+		return int(h.handRank)
+	}) {
+		if p, ok := pays[ThreeCardRank(h.value)]; ok {
+			total += p
+		} else {
+			total--
+		}
+	}
+
+	return -total / float64(binomial[52][3])
+}
@@ -0,0 +1,64 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_threeCardPokerSettle(t *testing.T) {
+	tests := []struct {
+		name   string
+		player [3]Card
+		dealer [3]Card
+		pair   int
+		play   bool
+		want   float64
+	}{
+		{
+			name:   "fold",
+			player: [3]Card{{Jack, Spade}, {Six, Heart}, {Two, Club}},
+			dealer: [3]Card{{King, Spade}, {Six, Diamond}, {Two, Diamond}},
+			play:   false,
+			want:   -10,
+		},
+		{
+			name:   "dealer does not qualify",
+			player: [3]Card{{Queen, Spade}, {Six, Heart}, {Four, Club}},
+			dealer: [3]Card{{Jack, Heart}, {Nine, Diamond}, {Two, Diamond}},
+			play:   true,
+			want:   10,
+		},
+		{
+			name:   "straight beats a flush with ante bonus and pair plus",
+			player: [3]Card{{Five, Spade}, {Six, Heart}, {Four, Club}},
+			dealer: [3]Card{{Ace, Diamond}, {Nine, Diamond}, {Two, Diamond}},
+			pair:   5,
+			play:   true,
+			want:   20 + 10 + 30,
+		},
+		{
+			name:   "lose to the dealer",
+			player: [3]Card{{King, Spade}, {Six, Heart}, {Four, Club}},
+			dealer: [3]Card{{Three, Diamond}, {Three, Club}, {Two, Diamond}},
+			pair:   5,
+			play:   true,
+			want:   -20 - 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := threeCardPokerSettle(tt.player, tt.dealer, 10, tt.pair, tt.play); got != tt.want {
+				t.Errorf("threeCardPokerSettle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_threeCardPokerEdge(t *testing.T) {
+	if got := threeCardPokerEdge(); math.Abs(got-0.033730) > 1e-6 {
+		t.Errorf("threeCardPokerEdge() = %.6f, want 0.033730", got)
+	}
+	if got := pairPlusEdge(pairPlus); math.Abs(got-0.023167) > 1e-6 {
+		t.Errorf("pairPlusEdge() = %.6f, want 0.023167", got)
+	}
+}
@@ -0,0 +1,424 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Clock tells a tournament the time. Tests drive it by hand so a whole
+// tournament plays out the same way every run.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// BlindLevel is one level of a blind schedule. The level ends once its
+// duration has passed or its hands have been played, whichever is set.
+type BlindLevel struct {
+	small    int
+	big      int
+	ante     int
+	duration time.Duration
+	hands    int
+}
+
+// blindSchedule keeps track of the current level. The last level lasts
+// until the tournament ends.
+type blindSchedule struct {
+	levels []BlindLevel
+	clock  Clock
+	level  int
+	start  time.Time
+	played int
+}
+
+func newBlindSchedule(levels []BlindLevel, clock Clock) *blindSchedule {
+	return &blindSchedule{levels: levels, clock: clock, start: clock.Now()}
+}
+
+// current moves on to the next level when the current one is over and
+// returns the level the next hand is played at.
+func (b *blindSchedule) current() BlindLevel {
+	for b.level < len(b.levels)-1 {
+		l := b.levels[b.level]
+		now := b.clock.Now()
+		timeUp := l.duration > 0 && now.Sub(b.start) >= l.duration
+		handsUp := l.hands > 0 && b.played >= l.hands
+		if !timeUp && !handsUp {
+			break
+		}
+		b.level++
+		b.played = 0
+		if timeUp {
+			b.start = b.start.Add(l.duration)
+		} else {
+			b.start = now
+		}
+	}
+
+	return b.levels[b.level]
+}
+
+// rebuyRule allows busted players back in through the given level, and
+// offers one add-on to everyone still in when that level is over.
+type rebuyRule struct {
+	cost       int
+	stack      int
+	max        int
+	lastLevel  int
+	addOnCost  int
+	addOnStack int
+}
+
+// Standing is a player's finishing place and prize.
+type Standing struct {
+	player *Player
+	place  int
+	prize  int
+}
+
+// Tournament runs a freezeout, or a rebuy tournament when a rebuy rule is
+// set, until one player has all the chips. A multi-table tournament keeps its
+// tables balanced and breaks them as the field shrinks.
+type Tournament struct {
+	tables   []*Table
+	blinds   *blindSchedule
+	buyIn    int
+	stack    int
+	rebuy    *rebuyRule
+	payouts  []float64
+	entrants []*Player
+	pool     int
+	rebuys   map[*Player]int
+	addOns   bool
+	// places holds the finishing place of every eliminated player.
+	places map[*Player]int
+	// dealt holds the prizes agreed in a deal, which replace the prizes for
+	// the places.
+	dealt map[*Player]int
+
+	// wantsRebuy and wantsAddOn decide for each player; both default to
+	// yes.
+	wantsRebuy func(p *Player) bool
+	wantsAddOn func(p *Player) bool
+}
+
+func newTournament(seats, buyIn, stack int, levels []BlindLevel, clock Clock) *Tournament {
+	return newMultiTableTournament(1, seats, buyIn, stack, levels, clock)
+}
+
+func newMultiTableTournament(tables, seats, buyIn, stack int, levels []BlindLevel, clock Clock) *Tournament {
+	t := make([]*Table, tables)
+	for i := range t {
+		t[i] = newTable(seats, levels[0].small, levels[0].big, levels[0].ante, DeadButton)
+		t[i].tournament = true
+	}
+
+	return &Tournament{
+		tables:     t,
+		blinds:     newBlindSchedule(levels, clock),
+		buyIn:      buyIn,
+		stack:      stack,
+		rebuys:     make(map[*Player]int),
+		places:     make(map[*Player]int),
+		dealt:      make(map[*Player]int),
+		wantsRebuy: func(*Player) bool { return true },
+		wantsAddOn: func(*Player) bool { return true },
+	}
+}
+
+// register buys a player in and seats them at the table with the fewest
+// players that has a free seat.
+func (t *Tournament) register(name string) (*Player, error) {
+	table := t.shortestOpen()
+	if table == nil {
+		return nil, fmt.Errorf("tournament is full")
+	}
+	s := table.openSeat()
+	p := &Player{name: name, stack: t.stack}
+	table.seats[s] = p
+	t.entrants = append(t.entrants, p)
+	t.pool += t.buyIn
+
+	return p, nil
+}
+
+// remaining returns how many players are still in.
+func (t *Tournament) remaining() int {
+	return len(t.entrants) - len(t.places)
+}
+
+// offerAddOns gives everyone still in the add-on once the rebuy period is
+// over.
+func (t *Tournament) offerAddOns() {
+	if t.rebuy == nil || t.addOns || t.blinds.level <= t.rebuy.lastLevel {
+		return
+	}
+	t.addOns = true
+	if t.rebuy.addOnStack == 0 {
+		return
+	}
+	for _, p := range t.entrants {
+		if _, out := t.places[p]; !out && t.wantsAddOn(p) {
+			p.stack += t.rebuy.addOnStack
+			t.pool += t.rebuy.addOnCost
+		}
+	}
+}
+
+// bust rebuys or eliminates the players who lost their chips in a hand.
+// Players knocked out on the same hand finish in order of the chips they
+// started it with.
+func (t *Tournament) bust(busted []*Player, before map[*Player]int) {
+	out := make([]*Player, 0, len(busted))
+	for _, p := range busted {
+		r := t.rebuy
+		if r != nil && t.blinds.level <= r.lastLevel && (r.max == 0 || t.rebuys[p] < r.max) && t.wantsRebuy(p) {
+			t.rebuys[p]++
+			p.stack = r.stack
+			t.pool += r.cost
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return before[out[i]] < before[out[j]] })
+
+	remaining := t.remaining()
+	for i, p := range out {
+		t.places[p] = remaining - i
+	}
+}
+
+// playHand plays one hand at every table at the current level. Tables play
+// in turn, so players busted at an earlier table finish behind those busted
+// at a later one, except hand for hand, when every table's busts count as
+// one hand.
+func (t *Tournament) playHand(engine HandEngine) error {
+	l := t.blinds.current()
+	t.offerAddOns()
+	handForHand := t.handForHand()
+
+	before := make(map[*Player]int)
+	busted := make([]*Player, 0)
+	for _, table := range t.tables {
+		if table.players() < 2 {
+			continue
+		}
+		table.small, table.big, table.ante = l.small, l.big, l.ante
+		for _, p := range table.seats {
+			if p != nil {
+				before[p] = p.stack
+			}
+		}
+		h, err := table.startHand()
+		if err != nil {
+			return err
+		}
+		if err := engine.play(table, h); err != nil {
+			return err
+		}
+		for _, p := range table.seats {
+			if p != nil && p.stack == 0 {
+				busted = append(busted, p)
+			}
+		}
+		if !handForHand {
+			t.bust(busted, before)
+			busted = busted[:0]
+		}
+	}
+	t.bust(busted, before)
+	t.blinds.played++
+
+	for _, table := range t.tables {
+		for s, p := range table.seats {
+			if _, out := t.places[p]; p != nil && out {
+				table.stand(s)
+			}
+		}
+	}
+	t.breakTables()
+	t.balance()
+	if t.remaining() == 1 {
+		for _, p := range t.entrants {
+			if _, out := t.places[p]; !out {
+				t.places[p] = 1
+			}
+		}
+	}
+
+	return nil
+}
+
+// handForHand reports whether the next elimination bursts the bubble with
+// more than one table left. Every table then plays one hand at a time.
+func (t *Tournament) handForHand() bool {
+	return len(t.tables) > 1 && t.remaining() == len(t.payoutFractions())+1
+}
+
+// shortest returns the table with the fewest players, the first on a tie.
+func (t *Tournament) shortest() *Table {
+	short := t.tables[0]
+	for _, table := range t.tables[1:] {
+		if table.seated() < short.seated() {
+			short = table
+		}
+	}
+	return short
+}
+
+// shortestOpen returns the table with the fewest players among those with a
+// free seat, the first on a tie, or nil when every table is full. Tables may
+// have different numbers of seats, so the shortest table can be full.
+func (t *Tournament) shortestOpen() *Table {
+	var short *Table
+	for _, table := range t.tables {
+		if table.openSeat() >= 0 && (short == nil || table.seated() < short.seated()) {
+			short = table
+		}
+	}
+	return short
+}
+
+// fullest returns the table with the most players, the first on a tie.
+func (t *Tournament) fullest() *Table {
+	full := t.tables[0]
+	for _, table := range t.tables[1:] {
+		if table.seated() > full.seated() {
+			full = table
+		}
+	}
+	return full
+}
+
+// move seats a player at the open seat the big blind reaches first, so they
+// are not skipped past the blinds.
+func (t *Tournament) move(p *Player, to *Table) {
+	s := to.openSeat()
+	to.seats[s] = p
+	p.missedBig = to.hands > 0
+}
+
+// breakTables breaks the shortest table while the players left fit in the
+// seats of the others, moving each player to the shortest of them with a
+// free seat.
+func (t *Tournament) breakTables() {
+	for len(t.tables) > 1 {
+		broken := t.shortest()
+		seats := 0
+		for _, table := range t.tables {
+			if table != broken {
+				seats += len(table.seats)
+			}
+		}
+		if t.remaining() > seats {
+			return
+		}
+		for i, table := range t.tables {
+			if table == broken {
+				t.tables = append(t.tables[:i], t.tables[i+1:]...)
+				break
+			}
+		}
+		for s, p := range broken.seats {
+			if p != nil {
+				broken.stand(s)
+				t.move(p, t.shortestOpen())
+			}
+		}
+	}
+}
+
+// balance moves players from the fullest table to the shortest until no two
+// tables differ by more than one player. The player moved is the one due the
+// big blind next, who takes the seat closest to the big blind at the new
+// table. Only tables with a free seat take players.
+func (t *Tournament) balance() {
+	for {
+		full, short := t.fullest(), t.shortestOpen()
+		if short == nil || full.seated()-short.seated() <= 1 {
+			return
+		}
+		s := full.next(full.lastBig)
+		t.move(full.stand(s), short)
+	}
+}
+
+// run plays hands until one player is left and returns the standings.
+func (t *Tournament) run(engine HandEngine) ([]Standing, error) {
+	if len(t.entrants) < 2 {
+		return nil, fmt.Errorf("not enough players")
+	}
+	for t.remaining() > 1 {
+		if err := t.playHand(engine); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.standings(), nil
+}
+
+// standings lists every eliminated player by place with the prize paid for
+// it.
+func (t *Tournament) standings() []Standing {
+	prizes := payoutAmounts(t.pool, t.payoutFractions())
+	standings := make([]Standing, 0, len(t.places))
+	for p, place := range t.places {
+		s := Standing{player: p, place: place}
+		if n, ok := t.dealt[p]; ok {
+			s.prize = n
+		} else if place <= len(prizes) {
+			s.prize = prizes[place-1]
+		}
+		standings = append(standings, s)
+	}
+	sort.Slice(standings, func(i, j int) bool { return standings[i].place < standings[j].place })
+
+	return standings
+}
+
+// payoutFractions returns the share of the pool paid to each place.
+func (t *Tournament) payoutFractions() []float64 {
+	if t.payouts != nil {
+		return t.payouts
+	}
+	return standardPayouts(len(t.entrants))
+}
+
+// payoutAmounts splits the prize pool by the payout fractions. Chips lost to
+// rounding go to first place.
+func payoutAmounts(pool int, payouts []float64) []int {
+	prizes := make([]int, len(payouts))
+	paid := 0
+	for i, f := range payouts {
+		prizes[i] = int(float64(pool) * f)
+		paid += prizes[i]
+	}
+	if len(prizes) > 0 {
+		prizes[0] += pool - paid
+	}
+
+	return prizes
+}
+
+// standardPayouts pays about the top fifth of the field, at most fifteen
+// places.
+func standardPayouts(entrants int) []float64 {
+	switch {
+	case entrants <= 6:
+		return []float64{0.65, 0.35}
+	case entrants <= 10:
+		return []float64{0.5, 0.3, 0.2}
+	case entrants <= 20:
+		return []float64{0.4, 0.25, 0.15, 0.12, 0.08}
+	case entrants <= 50:
+		return []float64{0.3, 0.2, 0.14, 0.1, 0.08, 0.065, 0.055, 0.03, 0.03}
+	}
+
+	return []float64{0.26, 0.16, 0.11, 0.085, 0.07, 0.06, 0.05, 0.04, 0.03,
+		0.025, 0.025, 0.025, 0.02, 0.02, 0.02}
+}
@@ -0,0 +1,321 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// manualClock only moves when a test moves it.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+// tickingEngine moves the clock on after every hand it plays.
+type tickingEngine struct {
+	HandEngine
+	clock *manualClock
+	tick  time.Duration
+}
+
+func (e tickingEngine) play(t *Table, h *TableHand) error {
+	e.clock.now = e.clock.now.Add(e.tick)
+	return e.HandEngine.play(t, h)
+}
+
+var testLevels = []BlindLevel{
+	{small: 5, big: 10, duration: 10 * time.Minute},
+	{small: 10, big: 20, duration: 10 * time.Minute},
+	{small: 25, big: 50, ante: 5, duration: 10 * time.Minute},
+	{small: 50, big: 100, ante: 10, duration: 10 * time.Minute},
+	{small: 100, big: 200, ante: 25},
+}
+
+func Test_blindSchedule(t *testing.T) {
+	clock := &manualClock{}
+	b := newBlindSchedule(testLevels, clock)
+	if got := b.current(); got != testLevels[0] {
+		t.Errorf("current() = %v, want %v", got, testLevels[0])
+	}
+	clock.now = clock.now.Add(25 * time.Minute)
+	if got := b.current(); got != testLevels[2] {
+		t.Errorf("current() after 25m = %v, want %v", got, testLevels[2])
+	}
+	clock.now = clock.now.Add(5 * time.Minute)
+	if got := b.current(); got != testLevels[3] {
+		t.Errorf("current() after 30m = %v, want %v", got, testLevels[3])
+	}
+	clock.now = clock.now.Add(time.Hour)
+	if got := b.current(); got != testLevels[4] {
+		t.Errorf("current() after 90m = %v, want the last level", got)
+	}
+
+	byHands := newBlindSchedule([]BlindLevel{{small: 1, big: 2, hands: 3}, {small: 2, big: 4}}, clock)
+	for i := 0; i < 3; i++ {
+		if got := byHands.current(); got.big != 2 {
+			t.Errorf("hand %d big blind = %d, want 2", i+1, got.big)
+		}
+		byHands.played++
+	}
+	if got := byHands.current(); got.big != 4 {
+		t.Errorf("hand 4 big blind = %d, want 4", got.big)
+	}
+}
+
+func runTestTournament(t *testing.T, seed int64, rebuy *rebuyRule) *Tournament {
+	t.Helper()
+	clock := &manualClock{}
+	tour := newTournament(6, 100, 1000, testLevels, clock)
+	tour.rebuy = rebuy
+	for _, name := range []string{"Ann", "Bob", "Cid", "Dee", "Eve", "Fay"} {
+		if _, err := tour.register(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	engine := tickingEngine{
+		HandEngine: showdownEngine{rng: rand.New(rand.NewSource(seed))},
+		clock:      clock,
+		tick:       time.Minute,
+	}
+	if _, err := tour.run(engine); err != nil {
+		t.Fatal(err)
+	}
+	return tour
+}
+
+func TestTournament_run(t *testing.T) {
+	tour := runTestTournament(t, 1, nil)
+	standings := tour.standings()
+	if len(standings) != 6 {
+		t.Fatalf("standings() = %d players, want 6", len(standings))
+	}
+	prizes := 0
+	for i, s := range standings {
+		if s.place != i+1 {
+			t.Errorf("standing %d place = %d", i, s.place)
+		}
+		prizes += s.prize
+	}
+	if prizes != 600 {
+		t.Errorf("prizes = %d, want the 600 pool", prizes)
+	}
+	if standings[0].prize != 390 || standings[1].prize != 210 {
+		t.Errorf("prizes = %d, %d, want 390 and 210", standings[0].prize, standings[1].prize)
+	}
+	if w := standings[0].player; w.stack != 6000 {
+		t.Errorf("winner stack = %d, want 6000", w.stack)
+	}
+
+	again := runTestTournament(t, 1, nil)
+	names := func(tour *Tournament) []string {
+		n := make([]string, 0)
+		for _, s := range tour.standings() {
+			n = append(n, s.player.name)
+		}
+		return n
+	}
+	if !reflect.DeepEqual(names(tour), names(again)) {
+		t.Errorf("same seed finished %v and %v", names(tour), names(again))
+	}
+}
+
+func TestTournament_rebuys(t *testing.T) {
+	rule := &rebuyRule{cost: 100, stack: 1000, max: 1, lastLevel: 1, addOnCost: 100, addOnStack: 1500}
+	tour := runTestTournament(t, 7, rule)
+	rebuys := 0
+	for _, n := range tour.rebuys {
+		if n > 1 {
+			t.Errorf("rebuys = %d, want at most 1", n)
+		}
+		rebuys += n
+	}
+	if !tour.addOns {
+		t.Errorf("add-ons never offered")
+	}
+	if tour.pool <= 600 {
+		t.Errorf("pool = %d, want rebuys and add-ons added", tour.pool)
+	}
+	chips := 0
+	for _, p := range tour.entrants {
+		chips += p.stack
+	}
+	if chips < 6000+rebuys*1000 {
+		t.Errorf("chips = %d, want every rebuy and add-on in play", chips)
+	}
+}
+
+func Test_payoutAmounts(t *testing.T) {
+	if got := payoutAmounts(1001, []float64{0.5, 0.3, 0.2}); !reflect.DeepEqual(got, []int{501, 300, 200}) {
+		t.Errorf("payoutAmounts() = %v, want [501 300 200]", got)
+	}
+	for _, n := range []int{2, 9, 18, 45, 300} {
+		total := 0.0
+		for _, f := range standardPayouts(n) {
+			total += f
+		}
+		if total < 0.999 || total > 1.001 {
+			t.Errorf("standardPayouts(%d) sums to %v", n, total)
+		}
+	}
+}
+
+func TestTournament_multiTable(t *testing.T) {
+	clock := &manualClock{}
+	tour := newMultiTableTournament(3, 9, 100, 1000, testLevels, clock)
+	for i := 0; i < 25; i++ {
+		if _, err := tour.register(string(rune('A' + i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, want := range []int{9, 8, 8} {
+		if got := tour.tables[i].seated(); got != want {
+			t.Errorf("table %d seated %d, want %d", i, got, want)
+		}
+	}
+
+	engine := tickingEngine{
+		HandEngine: showdownEngine{rng: rand.New(rand.NewSource(3))},
+		clock:      clock,
+		tick:       time.Minute,
+	}
+	handForHand := false
+	for tour.remaining() > 1 {
+		handForHand = handForHand || tour.handForHand()
+		if err := tour.playHand(engine); err != nil {
+			t.Fatal(err)
+		}
+		if tour.remaining() == 0 {
+			break
+		}
+		seated, min, max := 0, 9, 0
+		for _, table := range tour.tables {
+			n := table.seated()
+			seated += n
+			if n < min {
+				min = n
+			}
+			if n > max {
+				max = n
+			}
+		}
+		if seated != tour.remaining() {
+			t.Fatalf("%d players seated, %d remaining", seated, tour.remaining())
+		}
+		if max-min > 1 {
+			t.Fatalf("tables unbalanced: %d to %d players", min, max)
+		}
+		if want := (seated + 8) / 9; len(tour.tables) != want {
+			t.Fatalf("%d tables for %d players, want %d", len(tour.tables), seated, want)
+		}
+	}
+	if !handForHand {
+		t.Errorf("never played hand for hand")
+	}
+
+	standings := tour.standings()
+	prizes := 0
+	for i, s := range standings {
+		if s.place != i+1 {
+			t.Errorf("standing %d place = %d", i, s.place)
+		}
+		prizes += s.prize
+	}
+	if len(standings) != 25 || prizes != tour.pool {
+		t.Errorf("%d standings paid %d, want 25 paid %d", len(standings), prizes, tour.pool)
+	}
+}
+
+func TestTournament_balance(t *testing.T) {
+	tour := newMultiTableTournament(2, 6, 100, 1000, testLevels, &manualClock{})
+	for i := 0; i < 12; i++ {
+		tour.register(string(rune('A' + i)))
+	}
+	full, short := tour.tables[0], tour.tables[1]
+	full.startHand()
+	short.startHand()
+	short.stand(2)
+	short.stand(4)
+
+	due := full.seats[full.next(full.lastBig)]
+	seat := short.openSeat()
+	tour.balance()
+	if full.seated() != 5 || short.seated() != 5 {
+		t.Fatalf("seated %d and %d, want 5 and 5", full.seated(), short.seated())
+	}
+	if short.seats[seat] != due {
+		t.Errorf("moved %v to seat %d, want %v", short.seats[seat], seat, due)
+	}
+	h, err := short.startHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(h.seats, seat) || h.paid[seat] != 0 {
+		t.Errorf("moved player dealt in = %v paying %d, want dealt in for free", contains(h.seats, seat), h.paid[seat])
+	}
+	if h, _ = short.startHand(); h.big != seat {
+		t.Errorf("big blind on seat %d, want the moved player on seat %d", h.big, seat)
+	}
+}
+
+func TestTournament_breakTables_mixedSizes(t *testing.T) {
+	// mixed builds a tournament from tables given as their seats and the
+	// players sitting at them
+	mixed := func(tables ...[2]int) *Tournament {
+		tour := newMultiTableTournament(0, 0, 100, 1000, testLevels, &manualClock{})
+		for _, spec := range tables {
+			table := newTable(spec[0], 10, 20, 0, DeadButton)
+			table.tournament = true
+			for s := 0; s < spec[1]; s++ {
+				p := &Player{name: fmt.Sprint(len(tour.entrants)), stack: 1000}
+				table.seats[s] = p
+				tour.entrants = append(tour.entrants, p)
+			}
+			tour.tables = append(tour.tables, table)
+		}
+		return tour
+	}
+	tests := []struct {
+		name   string
+		tables [][2]int
+		want   []int
+	}{
+		// the six-max has room for one of the two players moved
+		{"one seat at the small table", [][2]int{{6, 5}, {9, 2}, {9, 6}}, []int{6, 7}},
+		// breaking the nine-max would leave a player without a seat, so
+		// the players are balanced instead
+		{"too few seats elsewhere", [][2]int{{6, 6}, {9, 2}, {6, 5}}, []int{4, 4, 5}},
+		{"into a larger table", [][2]int{{9, 3}, {6, 2}}, []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tour := mixed(tt.tables...)
+			tour.breakTables()
+			tour.balance()
+			got := make([]int, len(tour.tables))
+			seated := make(map[*Player]bool)
+			for i, table := range tour.tables {
+				got[i] = table.seated()
+				for _, p := range table.seats {
+					if p != nil {
+						seated[p] = true
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seated %v, want %v", got, tt.want)
+			}
+			if len(seated) != len(tour.entrants) {
+				t.Errorf("%d of %d players seated", len(seated), len(tour.entrants))
+			}
+		})
+	}
+
+	tour := mixed([2]int{6, 6}, [2]int{9, 8})
+	if _, err := tour.register("late"); err != nil || tour.tables[1].seated() != 9 {
+		t.Errorf("register() = %v, seating %d at the nine-max, want 9", err, tour.tables[1].seated())
+	}
+}
@@ -0,0 +1,104 @@
+package poker
+
+// uthBlindPays pays the blind when the player wins with a straight or better;
+// smaller winning hands push the blind.
+var uthBlindPays = payout{
+	royal: 500,
+	pays: map[HandRank]float64{
+		StraightFlush: 50,
+		FourOfAKind:   10,
+		FullHouse:     3,
+		Flush:         1.5,
+		Straight:      1,
+	},
+}
+
+// uthTripsPays pays on the player's hand alone, win or lose against the
+// dealer.
+var uthTripsPays = payout{
+	royal: 50,
+	pays: map[HandRank]float64{
+		StraightFlush: 40,
+		FourOfAKind:   30,
+		FullHouse:     9,
+		Flush:         7,
+		Straight:      4,
+		ThreeOfAKind:  3,
+	},
+}
+
+// uthQualifier is the lowest dealer hand that opens, a pair of deuces.
+var uthQualifier = packValue(Pair, [5]Rank{Two})
+
+// uthBets are the wagers of one Ultimate Texas Hold'em hand. A zero play bet
+// means the player folded.
+type uthBets struct {
+	ante, blind, play, trips int
+}
+
+// uthDeal deals the player's and the dealer's hole cards and the board.
+func uthDeal() (hole, dealer [2]Card, board [5]Card) {
+	cards, _ := newStub().draw(9)
+	return [2]Card(cards[:2]), [2]Card(cards[2:4]), [5]Card(cards[4:])
+}
+
+// uthSettle returns the player's net result for one hand. If the dealer does
+// not open the ante pushes, and the blind only pays on a straight or better.
+func uthSettle(hole, dealer [2]Card, board [5]Card, bets uthBets) float64 {
+	p := evaluate([]Card{hole[0], hole[1], board[0], board[1], board[2], board[3], board[4]})
+	d := evaluate([]Card{dealer[0], dealer[1], board[0], board[1], board[2], board[3], board[4]})
+
+	net := 0.0
+	if bets.trips > 0 {
+		if pays := uthTripsPays.pay(p); pays > 0 {
+			net += float64(bets.trips) * pays
+		} else {
+			net -= float64(bets.trips)
+		}
+	}
+	if bets.play == 0 {
+		return net - float64(bets.ante+bets.blind)
+	}
+
+	ante := float64(bets.ante)
+	if d < uthQualifier {
+		ante = 0
+	}
+	switch {
+	case p > d:
+		net += ante + float64(bets.play) + float64(bets.blind)*uthBlindPays.pay(p)
+	case p < d:
+		net -= ante + float64(bets.play+bets.blind)
+	}
+
+	return net
+}
+
+// tripsEdge enumerates every seven-card hand and returns the house edge of a
+// Trips paytable.
+func tripsEdge(pays payout) float64 {
+	var total float64
+	var n int64
+	cards := deck()
+	var m [8]suitMasks
+	var rec func(k, from int)
+	rec = func(k, from int) {
+		if k == 7 {
+			if p := pays.pay(m[7].value()); p > 0 {
+				total += p
+			} else {
+				total--
+			}
+			n++
+			return
+		}
+		for i := from; i < 52; i++ {
+			m[k+1] = m[k]
+			m[k+1].add(cards[i])
+			rec(k+1, i+1)
+		}
+	}
+	rec(0, 0)
+
+	return -total / float64(n)
+}
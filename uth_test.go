@@ -0,0 +1,77 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_uthSettle(t *testing.T) {
+	tests := []struct {
+		name   string
+		hole   [2]Card
+		dealer [2]Card
+		board  [5]Card
+		bets   uthBets
+		want   float64
+	}{
+		{
+			name:   "fold",
+			hole:   [2]Card{{Seven, Spade}, {Two, Heart}},
+			dealer: [2]Card{{Ace, Spade}, {Ace, Heart}},
+			board:  [5]Card{{King, Club}, {Nine, Diamond}, {Four, Club}, {Three, Heart}, {Jack, Spade}},
+			bets:   uthBets{ante: 10, blind: 10},
+			want:   -20,
+		},
+		{
+			name:   "flush pays three to two on the blind",
+			hole:   [2]Card{{Seven, Club}, {Two, Club}},
+			dealer: [2]Card{{Ace, Spade}, {Ace, Heart}},
+			board:  [5]Card{{King, Club}, {Nine, Diamond}, {Four, Club}, {Three, Club}, {Jack, Spade}},
+			bets:   uthBets{ante: 10, blind: 10, play: 40, trips: 5},
+			want:   10 + 40 + 15 + 35,
+		},
+		{
+			name:   "dealer does not open",
+			hole:   [2]Card{{Ace, Club}, {Queen, Diamond}},
+			dealer: [2]Card{{Eight, Spade}, {Six, Heart}},
+			board:  [5]Card{{King, Club}, {Ten, Diamond}, {Four, Club}, {Three, Heart}, {Two, Spade}},
+			bets:   uthBets{ante: 10, blind: 10, play: 40},
+			want:   40,
+		},
+		{
+			name:   "lose to a dealer who does not open",
+			hole:   [2]Card{{Seven, Club}, {Six, Diamond}},
+			dealer: [2]Card{{Ace, Spade}, {Eight, Heart}},
+			board:  [5]Card{{King, Club}, {Ten, Diamond}, {Four, Club}, {Three, Heart}, {Two, Spade}},
+			bets:   uthBets{ante: 10, blind: 10, play: 10},
+			want:   -20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uthSettle(tt.hole, tt.dealer, tt.board, tt.bets); got != tt.want {
+				t.Errorf("uthSettle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_uthDeal(t *testing.T) {
+	hole, dealer, board := uthDeal()
+	seen := make(map[Card]bool)
+	for _, c := range append(append(hole[:], dealer[:]...), board[:]...) {
+		if seen[c] {
+			t.Errorf("uthDeal() dealt %v twice", c)
+		}
+		seen[c] = true
+	}
+}
+
+func Test_tripsEdge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every seven-card hand")
+	}
+	if got := tripsEdge(uthTripsPays); math.Abs(got-0.009018) > 1e-6 {
+		t.Errorf("tripsEdge() = %.6f, want 0.009018", got)
+	}
+}
@@ -0,0 +1,416 @@
+package poker
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// Paytable is a video poker game: how a final hand is classified into a pay
+// line and what each line pays per coin bet.
+type Paytable struct {
+	name string
+	pays map[string]int
+	line func(cards [5]Card) string
+}
+
+func (p *Paytable) pay(cards [5]Card) int {
+	return p.pays[p.line(cards)]
+}
+
+// jacksOrBetter is full-pay 9/6 Jacks or Better.
+var jacksOrBetter = &Paytable{
+	name: "Jacks or Better",
+	pays: map[string]int{
+		"Royal Flush":     800,
+		"Straight Flush":  50,
+		"Four of a Kind":  25,
+		"Full House":      9,
+		"Flush":           6,
+		"Straight":        4,
+		"Three of a Kind": 3,
+		"Two Pair":        2,
+		"Jacks or Better": 1,
+	},
+	line: func(cards [5]Card) string {
+		return standardLine(cards, "Four of a Kind", 1)
+	},
+}
+
+// doubleBonus is full-pay 10/7 Double Bonus, which pays extra for quads by
+// rank.
+var doubleBonus = &Paytable{
+	name: "Double Bonus",
+	pays: map[string]int{
+		"Royal Flush":     800,
+		"Straight Flush":  50,
+		"Four Aces":       160,
+		"Four 2-4":        80,
+		"Four 5-K":        50,
+		"Full House":      10,
+		"Flush":           7,
+		"Straight":        5,
+		"Three of a Kind": 3,
+		"Two Pair":        1,
+		"Jacks or Better": 1,
+	},
+	line: func(cards [5]Card) string {
+		h := Hand{cards: cards}
+		h.score()
+		if h.handRank == FourOfAKind {
+			switch {
+			case h.ranks[0] == Ace:
+				return "Four Aces"
+			case h.ranks[0] <= Four:
+				return "Four 2-4"
+			}
+			return "Four 5-K"
+		}
+		return standardLine(cards, "", 1)
+	},
+}
+
+// deucesWild is full-pay Deuces Wild, where every deuce substitutes for any
+// card and the lowest paying hand is three of a kind.
+var deucesWild = &Paytable{
+	name: "Deuces Wild",
+	pays: map[string]int{
+		"Natural Royal Flush": 800,
+		"Four Deuces":         200,
+		"Wild Royal Flush":    25,
+		"Five of a Kind":      15,
+		"Straight Flush":      9,
+		"Four of a Kind":      5,
+		"Full House":          3,
+		"Flush":               2,
+		"Straight":            2,
+		"Three of a Kind":     1,
+	},
+	line: deucesWildLine,
+}
+
+var standardLines = map[HandRank]string{
+	StraightFlush: "Straight Flush",
+	FourOfAKind:   "Four of a Kind",
+	FullHouse:     "Full House",
+	Flush:         "Flush",
+	Straight:      "Straight",
+	ThreeOfAKind:  "Three of a Kind",
+	TwoPair:       "Two Pair",
+}
+
+// standardLine classifies a hand without wild cards. Quads are named by the
+// caller's quads line, and pairs pay from jacks up when minPair is set.
+func standardLine(cards [5]Card, quads string, minPair int) string {
+	h := Hand{cards: cards}
+	h.score()
+	switch {
+	case royal(&h):
+		return "Royal Flush"
+	case h.handRank == FourOfAKind:
+		return quads
+	case h.handRank == Pair && minPair > 0 && h.ranks[0] >= Jack:
+		return "Jacks or Better"
+	}
+
+	return standardLines[h.handRank]
+}
+
+// straightWindow reports whether distinct ranks fit in five consecutive
+// ranks, counting an ace as high or low, and returns the top of the highest
+// window that fits.
+func straightWindow(ranks []Rank) (Rank, bool) {
+	var mask uint16
+	for _, r := range ranks {
+		if mask&(1<<r) != 0 {
+			return 0, false
+		}
+		mask |= 1 << r
+	}
+	if mask&(1<<Ace) != 0 {
+		mask |= 1 << 1
+	}
+	for top := Ace; top >= Five; top-- {
+		window := uint16(0x1f) << (top - 4)
+		ace := mask
+		if top == Five {
+			ace &^= 1 << Ace
+		} else {
+			ace &^= 1 << 1
+		}
+		if ace&^window == 0 {
+			return top, true
+		}
+	}
+
+	return 0, false
+}
+
+func deucesWildLine(cards [5]Card) string {
+	wild := 0
+	others := make([]Rank, 0, 5)
+	count := make(map[Rank]int)
+	suits := make(map[Suit]bool)
+	for _, c := range cards {
+		if c.rank == Two {
+			wild++
+			continue
+		}
+		others = append(others, c.rank)
+		count[c.rank]++
+		suits[c.suit] = true
+	}
+	if wild == 0 {
+		h := Hand{cards: cards}
+		h.score()
+		if royal(&h) {
+			return "Natural Royal Flush"
+		}
+		if h.handRank < ThreeOfAKind {
+			return ""
+		}
+		return standardLines[h.handRank]
+	}
+	if wild == 4 {
+		return "Four Deuces"
+	}
+
+	most := 0
+	for _, n := range count {
+		if n > most {
+			most = n
+		}
+	}
+	flush := len(suits) == 1
+	top, straight := straightWindow(others)
+	switch {
+	case flush && straight && top == Ace:
+		return "Wild Royal Flush"
+	case most+wild == 5:
+		return "Five of a Kind"
+	case flush && straight:
+		return "Straight Flush"
+	case most+wild == 4:
+		return "Four of a Kind"
+	case wild == 1 && len(count) == 2:
+		return "Full House"
+	case flush:
+		return "Flush"
+	case straight:
+		return "Straight"
+	case most+wild == 3:
+		return "Three of a Kind"
+	}
+
+	return ""
+}
+
+// cardIndex numbers cards in deck order.
+func cardIndex(c Card) int {
+	return int(c.suit)*13 + int(c.rank-2)
+}
+
+var binomial [53][6]int64
+
+func init() {
+	for n := range binomial {
+		binomial[n][0] = 1
+		for k := 1; k < len(binomial[n]) && k <= n; k++ {
+			binomial[n][k] = binomial[n-1][k-1]
+			if k < n {
+				binomial[n][k] += binomial[n-1][k]
+			}
+		}
+	}
+}
+
+// colex ranks a set of card indices, given in ascending order, among the sets
+// of the same size.
+func colex(idx []int) int64 {
+	var r int64
+	for i, c := range idx {
+		r += binomial[c][i+1]
+	}
+	return r
+}
+
+// vpSolver holds, for every set of up to five cards, the total pay of all
+// final hands that contain it. The expected value of any hold follows by
+// inclusion-exclusion over the discarded cards.
+type vpSolver struct {
+	table  *Paytable
+	totals [6][]int64
+}
+
+func newVPSolver(p *Paytable) *vpSolver {
+	s := &vpSolver{table: p}
+	for k := range s.totals {
+		s.totals[k] = make([]int64, binomial[52][k])
+	}
+
+	cards := deck()
+	var idx [5]int
+	var sub [5]int
+	for idx[0] = 0; idx[0] < 52; idx[0]++ {
+		for idx[1] = idx[0] + 1; idx[1] < 52; idx[1]++ {
+			for idx[2] = idx[1] + 1; idx[2] < 52; idx[2]++ {
+				for idx[3] = idx[2] + 1; idx[3] < 52; idx[3]++ {
+					for idx[4] = idx[3] + 1; idx[4] < 52; idx[4]++ {
+						hand := [5]Card{cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]]}
+						pay := int64(p.pay(hand))
+						if pay == 0 {
+							continue
+						}
+						for mask := 0; mask < 32; mask++ {
+							n := 0
+							for i := range idx {
+								if mask&(1<<i) != 0 {
+									sub[n] = idx[i]
+									n++
+								}
+							}
+							s.totals[n][colex(sub[:n])] += pay
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return s
+}
+
+// holdEV returns the expected pay per coin of keeping the held cards and
+// drawing to the rest.
+func (s *vpSolver) holdEV(cards [5]Card, hold [5]bool) float64 {
+	held := make([]int, 0, 5)
+	drop := make([]int, 0, 5)
+	for i, c := range cards {
+		if hold[i] {
+			held = append(held, cardIndex(c))
+		} else {
+			drop = append(drop, cardIndex(c))
+		}
+	}
+
+	var total int64
+	set := make([]int, 0, 5)
+	for mask := 0; mask < 1<<len(drop); mask++ {
+		set = append(set[:0], held...)
+		for i, c := range drop {
+			if mask&(1<<i) != 0 {
+				set = append(set, c)
+			}
+		}
+		sort.Ints(set)
+		t := s.totals[len(set)][colex(set)]
+		if bits.OnesCount(uint(mask))%2 == 1 {
+			t = -t
+		}
+		total += t
+	}
+
+	return float64(total) / float64(binomial[47][len(drop)])
+}
+
+// bestHold tries all 32 ways to hold the dealt cards and returns the one with
+// the highest expected pay.
+func (s *vpSolver) bestHold(cards [5]Card) ([5]bool, float64) {
+	var best [5]bool
+	max := -1.0
+	for mask := 0; mask < 32; mask++ {
+		var hold [5]bool
+		for i := range hold {
+			hold[i] = mask&(1<<i) != 0
+		}
+		if ev := s.holdEV(cards, hold); ev > max {
+			best, max = hold, ev
+		}
+	}
+
+	return best, max
+}
+
+// canonicalDeal maps a deal to a representative under suit permutations by
+// ordering the per-suit rank masks.
+func canonicalDeal(cards [5]Card) uint64 {
+	var masks [4]uint64
+	for _, c := range cards {
+		masks[c.suit] |= 1 << (c.rank - 2)
+	}
+	sort.Slice(masks[:], func(i, j int) bool { return masks[i] > masks[j] })
+
+	return masks[0] | masks[1]<<13 | masks[2]<<26 | masks[3]<<39
+}
+
+func dealFromCanonical(key uint64) [5]Card {
+	var cards [5]Card
+	n := 0
+	for i := 0; i < 52; i++ {
+		if key&(1<<i) != 0 {
+			cards[n] = Card{rank: Rank(i%13 + 2), suit: Suit(i / 13)}
+			n++
+		}
+	}
+	return cards
+}
+
+// returnRate plays every possible deal with the optimal hold and returns the
+// game's long-run return per coin bet.
+func (s *vpSolver) returnRate() float64 {
+	weights := make(map[uint64]int)
+	cards := deck()
+	var idx [5]int
+	for idx[0] = 0; idx[0] < 52; idx[0]++ {
+		for idx[1] = idx[0] + 1; idx[1] < 52; idx[1]++ {
+			for idx[2] = idx[1] + 1; idx[2] < 52; idx[2]++ {
+				for idx[3] = idx[2] + 1; idx[3] < 52; idx[3]++ {
+					for idx[4] = idx[3] + 1; idx[4] < 52; idx[4]++ {
+						weights[canonicalDeal([5]Card{cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]]})]++
+					}
+				}
+			}
+		}
+	}
+
+	var total float64
+	for key, n := range weights {
+		_, ev := s.bestHold(dealFromCanonical(key))
+		total += ev * float64(n)
+	}
+
+	return total / float64(binomial[52][5])
+}
+
+// videoPoker runs the deal, hold and draw cycle of a single machine.
+type videoPoker struct {
+	table *Paytable
+	stub  *stub
+	hand  [5]Card
+}
+
+func (v *videoPoker) deal() [5]Card {
+	v.stub = newStub()
+	cards, _ := v.stub.draw(5)
+	v.hand = [5]Card(cards)
+
+	return v.hand
+}
+
+// draw replaces the cards that are not held and returns the final hand and
+// its pay per coin.
+func (v *videoPoker) draw(hold [5]bool) ([5]Card, int, error) {
+	if v.stub == nil {
+		return v.hand, 0, fmt.Errorf("no hand dealt")
+	}
+	for i := range v.hand {
+		if hold[i] {
+			continue
+		}
+		c, _ := v.stub.draw(1)
+		v.hand[i] = c[0]
+	}
+	v.stub = nil
+
+	return v.hand, v.table.pay(v.hand), nil
+}
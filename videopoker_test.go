@@ -0,0 +1,166 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPaytable_line(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *Paytable
+		cards [5]Card
+		want  string
+	}{
+		{
+			name:  "jacks",
+			table: jacksOrBetter,
+			cards: [5]Card{{Jack, Spade}, {Jack, Heart}, {Two, Club}, {Five, Club}, {Nine, Diamond}},
+			want:  "Jacks or Better",
+		},
+		{
+			name:  "tens do not pay",
+			table: jacksOrBetter,
+			cards: [5]Card{{Ten, Spade}, {Ten, Heart}, {Two, Club}, {Five, Club}, {Nine, Diamond}},
+			want:  "",
+		},
+		{
+			name:  "royal",
+			table: jacksOrBetter,
+			cards: [5]Card{{Ten, Heart}, {Jack, Heart}, {Queen, Heart}, {King, Heart}, {Ace, Heart}},
+			want:  "Royal Flush",
+		},
+		{
+			name:  "four aces",
+			table: doubleBonus,
+			cards: [5]Card{{Ace, Spade}, {Ace, Heart}, {Ace, Club}, {Ace, Diamond}, {Nine, Diamond}},
+			want:  "Four Aces",
+		},
+		{
+			name:  "four threes",
+			table: doubleBonus,
+			cards: [5]Card{{Three, Spade}, {Three, Heart}, {Three, Club}, {Three, Diamond}, {Nine, Diamond}},
+			want:  "Four 2-4",
+		},
+		{
+			name:  "four deuces",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {Two, Heart}, {Two, Club}, {Two, Diamond}, {Nine, Diamond}},
+			want:  "Four Deuces",
+		},
+		{
+			name:  "wild royal",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {Jack, Heart}, {Queen, Heart}, {King, Heart}, {Ace, Heart}},
+			want:  "Wild Royal Flush",
+		},
+		{
+			name:  "five of a kind",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {Two, Heart}, {Nine, Club}, {Nine, Heart}, {Nine, Diamond}},
+			want:  "Five of a Kind",
+		},
+		{
+			name:  "wheel straight flush",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {Ace, Club}, {Three, Club}, {Five, Club}, {Four, Club}},
+			want:  "Straight Flush",
+		},
+		{
+			name:  "wild full house",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {Seven, Club}, {Seven, Heart}, {Five, Club}, {Five, Diamond}},
+			want:  "Full House",
+		},
+		{
+			name:  "wild pair does not pay",
+			table: deucesWild,
+			cards: [5]Card{{Two, Spade}, {King, Club}, {Seven, Heart}, {Five, Club}, {Nine, Diamond}},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.line(tt.cards); got != tt.want {
+				t.Errorf("line() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_vpSolver(t *testing.T) {
+	s := newVPSolver(jacksOrBetter)
+
+	tests := []struct {
+		name  string
+		cards [5]Card
+		want  [5]bool
+	}{
+		{
+			name:  "break a flush for four to a royal",
+			cards: [5]Card{{Ace, Spade}, {King, Spade}, {Queen, Spade}, {Jack, Spade}, {Three, Spade}},
+			want:  [5]bool{true, true, true, true, false},
+		},
+		{
+			name:  "low pair over two high cards",
+			cards: [5]Card{{Five, Spade}, {Ace, Heart}, {Five, Club}, {King, Diamond}, {Nine, Spade}},
+			want:  [5]bool{true, false, true, false, false},
+		},
+		{
+			name:  "keep a pat straight",
+			cards: [5]Card{{Five, Spade}, {Six, Heart}, {Seven, Club}, {Eight, Diamond}, {Nine, Spade}},
+			want:  [5]bool{true, true, true, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := s.bestHold(tt.cards); got != tt.want {
+				t.Errorf("bestHold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	straight := [5]Card{{Five, Spade}, {Six, Heart}, {Seven, Club}, {Eight, Diamond}, {Nine, Spade}}
+	if ev := s.holdEV(straight, [5]bool{true, true, true, true, true}); ev != 4 {
+		t.Errorf("holdEV() = %v, want 4", ev)
+	}
+}
+
+func TestPaytable_returnRate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every deal")
+	}
+	tests := []struct {
+		table *Paytable
+		want  float64
+	}{
+		{table: jacksOrBetter, want: 0.995439},
+		{table: deucesWild, want: 1.007620},
+		{table: doubleBonus, want: 1.001725},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table.name, func(t *testing.T) {
+			if got := newVPSolver(tt.table).returnRate(); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("returnRate() = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_videoPoker(t *testing.T) {
+	v := videoPoker{table: jacksOrBetter}
+	if _, _, err := v.draw([5]bool{}); err == nil {
+		t.Errorf("draw() error = nil, want no hand dealt")
+	}
+	dealt := v.deal()
+	hand, pay, err := v.draw([5]bool{true, true})
+	if err != nil {
+		t.Fatalf("draw() error = %v", err)
+	}
+	if hand[0] != dealt[0] || hand[1] != dealt[1] {
+		t.Errorf("draw() = %v, want %v held", hand, dealt[:2])
+	}
+	if want := jacksOrBetter.pay(hand); pay != want {
+		t.Errorf("draw() pay = %d, want %d", pay, want)
+	}
+}